@@ -0,0 +1,268 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Command policytest runs declarative test suites against IAM policies
+// without a MinIO server.
+//
+// Policies are read from the JSON files of a directory, each named after
+// its file without the ".json" extension. Test suites are YAML files
+// holding a list of cases such as
+//
+//	# home.yaml
+//	- name: alice reads her home
+//	  policies: [home]
+//	  principal: alice
+//	  groups: [dev]
+//	  action: s3:GetObject
+//	  resource: arn:aws:s3:::home/alice/notes.txt
+//	  conditions:
+//	    username: [alice]
+//	  expect: Allow
+//
+// where expect is required and one of Allow, Deny or NoDecision. Cases
+// without policies are evaluated against all policies. The decisions of the
+// policies are combined like policy.IsAllowedSerial(). A table of results is
+// printed and the command exits with status 1 if any case fails, or 2 on
+// errors.
+//
+// Usage:
+//
+//	policytest [-policies DIR] [-tests DIR]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/pkg/v3/console"
+	"github.com/minio/pkg/v3/policy"
+	"gopkg.in/yaml.v3"
+)
+
+// testCase - a single request of a test suite and its expected decision.
+type testCase struct {
+	Name       string              `yaml:"name,omitempty"`
+	Policies   []string            `yaml:"policies,omitempty"`
+	Principal  string              `yaml:"principal,omitempty"`
+	Groups     []string            `yaml:"groups,omitempty"`
+	Action     policy.Action       `yaml:"action"`
+	Resource   string              `yaml:"resource,omitempty"`
+	Conditions map[string][]string `yaml:"conditions,omitempty"`
+	Owner      bool                `yaml:"owner,omitempty"`
+	DenyOnly   bool                `yaml:"denyOnly,omitempty"`
+	// Expect is a pointer so that a missing expectation is detected
+	// instead of silently expecting NoDecision.
+	Expect *policy.Decision `yaml:"expect"`
+}
+
+// simulationCase - returns the simulation case of the test case, where the
+// resource is an S3 ARN or a plain "bucket/object" path.
+func (c testCase) simulationCase() policy.SimulationCase {
+	resource := strings.TrimPrefix(c.Resource, policy.ResourceARNPrefix)
+	bucket, object, _ := strings.Cut(resource, "/")
+	return policy.SimulationCase{
+		Name:       c.Name,
+		Policies:   c.Policies,
+		Account:    c.Principal,
+		Groups:     c.Groups,
+		Action:     c.Action,
+		Bucket:     bucket,
+		Object:     object,
+		Conditions: c.Conditions,
+		IsOwner:    c.Owner,
+		DenyOnly:   c.DenyOnly,
+		Expect:     *c.Expect,
+	}
+}
+
+// suite - test cases read from a single file.
+type suite struct {
+	file  string
+	cases []policy.SimulationCase
+}
+
+// readPolicies - parses all JSON policies of the directory.
+func readPolicies(dir string) (map[string]policy.Policy, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no policies found in %v", dir)
+	}
+
+	policies := make(map[string]policy.Policy, len(files))
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		p, err := policy.ParseConfig(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", file, err)
+		}
+		policies[strings.TrimSuffix(filepath.Base(file), ".json")] = *p
+	}
+	return policies, nil
+}
+
+// readSuite - parses the test cases of a YAML file.
+func readSuite(file string) (suite, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return suite{}, err
+	}
+	defer f.Close()
+
+	var cases []testCase
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err = decoder.Decode(&cases); err != nil && !errors.Is(err, io.EOF) {
+		return suite{}, fmt.Errorf("%v: %w", file, err)
+	}
+
+	s := suite{file: filepath.Base(file)}
+	for i, c := range cases {
+		if c.Action == "" {
+			return suite{}, fmt.Errorf("%v: case %v: action must not be empty", file, i+1)
+		}
+		if c.Expect == nil {
+			return suite{}, fmt.Errorf("%v: case %v: expect must be set", file, i+1)
+		}
+		s.cases = append(s.cases, c.simulationCase())
+	}
+	return s, nil
+}
+
+// readSuites - parses all YAML test suites of the directory.
+func readSuites(dir string) ([]suite, error) {
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no test files found in %v", dir)
+	}
+	sort.Strings(files)
+
+	suites := make([]suite, 0, len(files))
+	for _, file := range files {
+		s, err := readSuite(file)
+		if err != nil {
+			return nil, err
+		}
+		suites = append(suites, s)
+	}
+	return suites, nil
+}
+
+// run - runs the command with given arguments and returns its exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("policytest", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	policiesDir := flags.String("policies", ".", "directory of JSON policies")
+	testsDir := flags.String("tests", "", "directory of YAML test files (default: the policies directory)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments: %v\n", flags.Args())
+		return 2
+	}
+	if *testsDir == "" {
+		*testsDir = *policiesDir
+	}
+
+	policies, err := readPolicies(*policiesDir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	suites, err := readSuites(*testsDir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	headerColor := color.New(color.Bold)
+	passColor := color.New(color.FgGreen)
+	failColor := color.New(color.FgRed, color.Bold)
+
+	rows := [][]string{{"File", "Case", "Action", "Resource", "Expected", "Decision", "Result"}}
+	rowColors := []*color.Color{headerColor}
+	var passed, failed int
+
+	simulator := policy.NewSimulator(policies)
+	for _, s := range suites {
+		report, err := simulator.Simulate(s.cases)
+		if err != nil {
+			fmt.Fprintf(stderr, "%v: %v\n", s.file, err)
+			return 2
+		}
+		for i, result := range report.Results {
+			name := result.Case.Name
+			if name == "" {
+				name = strconv.Itoa(i + 1)
+			}
+			resource := result.Case.Bucket
+			if result.Case.Object != "" {
+				resource += "/" + result.Case.Object
+			}
+			outcome, rowColor := "PASS", passColor
+			if result.Passed {
+				passed++
+			} else {
+				outcome, rowColor = "FAIL", failColor
+				failed++
+			}
+			rows = append(rows, []string{
+				s.file, name, string(result.Case.Action), resource,
+				result.Case.Expect.String(), result.Decision.String(), outcome,
+			})
+			rowColors = append(rowColors, rowColor)
+		}
+	}
+
+	table := console.NewTable(rowColors, make([]bool, len(rows[0])), 0)
+	if err = table.PopulateTable(stdout, rows); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	fmt.Fprintf(stdout, "%v passed, %v failed\n", passed, failed)
+
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const homePolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::home/${aws:username}/*"},
+    {"Effect": "Deny", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::home/*/private/*"}
+  ]
+}`
+
+const homeTests = `
+- name: alice reads her home
+  principal: alice
+  action: s3:GetObject
+  resource: arn:aws:s3:::home/alice/notes.txt
+  conditions:
+    username: [alice]
+  expect: Allow
+- name: alice reads private
+  action: s3:GetObject
+  resource: home/alice/private/key
+  conditions:
+    username: [alice]
+  expect: Deny
+- action: s3:PutObject
+  resource: home/alice/notes.txt
+  expect: NoDecision
+`
+
+func writeTestFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatalf("unexpected error. %v\n", err)
+		}
+	}
+	return dir
+}
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		files          map[string]string
+		expectedStatus int
+		expectedOutput string
+	}{
+		{map[string]string{"home.json": homePolicy, "home.yaml": homeTests}, 0, "3 passed, 0 failed"},
+		{map[string]string{"home.json": homePolicy, "home.yml": strings.Replace(homeTests, "expect: Deny", "expect: Allow", 1)}, 1, "2 passed, 1 failed"},
+		{map[string]string{"home.json": homePolicy}, 2, ""},
+		{map[string]string{"home.yaml": homeTests}, 2, ""},
+		{map[string]string{"home.json": `{"Version": "2012-10-17"`, "home.yaml": homeTests}, 2, ""},
+		{map[string]string{"home.json": homePolicy, "home.yaml": "- action: s3:GetObject\n  expect: Allow\n  bucket: home\n"}, 2, ""},
+		{map[string]string{"home.json": homePolicy, "home.yaml": "- action: s3:GetObject\n  policies: [other]\n  expect: Allow\n"}, 2, ""},
+		{map[string]string{"home.json": homePolicy, "home.yaml": "- action: s3:PutObject\n  resource: home/alice/notes.txt\n"}, 2, ""},
+	}
+
+	for i, testCase := range testCases {
+		dir := writeTestFiles(t, testCase.files)
+		var stdout, stderr bytes.Buffer
+		status := run([]string{"-policies", dir}, &stdout, &stderr)
+
+		if status != testCase.expectedStatus {
+			t.Fatalf("case %v: expected: %v, got: %v (%v)", i+1, testCase.expectedStatus, status, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), testCase.expectedOutput) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedOutput, stdout.String())
+		}
+	}
+}
+
+func TestRunSeparateDirs(t *testing.T) {
+	policies := writeTestFiles(t, map[string]string{"home.json": homePolicy})
+	tests := writeTestFiles(t, map[string]string{"home.yaml": homeTests})
+
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"-policies", policies, "-tests", tests}, &stdout, &stderr); status != 0 {
+		t.Fatalf("expected: 0, got: %v (%v)", status, stderr.String())
+	}
+	for _, s := range []string{"alice reads her home", "home/alice/private/key", "NoDecision", "PASS"} {
+		if !strings.Contains(stdout.String(), s) {
+			t.Fatalf("expected %v in output %v", s, stdout.String())
+		}
+	}
+
+	if status := run([]string{"-policies", policies, "extra"}, &stdout, &stderr); status != 2 {
+		t.Fatalf("expected: 2, got: %v", status)
+	}
+}
+
+func TestReadSuite(t *testing.T) {
+	testCases := []struct {
+		data      string
+		expectErr bool
+	}{
+		{homeTests, false},
+		{"", false},
+		// missing expect.
+		{"- action: s3:PutObject\n  resource: home/alice/notes.txt\n", true},
+		{strings.Replace(homeTests, "  expect: NoDecision\n", "", 1), true},
+		// misspelled expect.
+		{"- action: s3:PutObject\n  expected: Allow\n", true},
+	}
+
+	for i, testCase := range testCases {
+		dir := writeTestFiles(t, map[string]string{"home.yaml": testCase.data})
+		_, err := readSuite(filepath.Join(dir, "home.yaml"))
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v, %v", i+1, testCase.expectErr, expectErr, err)
+		}
+	}
+}
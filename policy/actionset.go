@@ -104,7 +104,9 @@ func (actionSet ActionSet) MarshalJSON() ([]byte, error) {
 	if len(actionSet) == 0 {
 		return nil, Errorf("empty actions not allowed")
 	}
-	return json.Marshal(actionSet.ToSlice())
+	actions := actionSet.ToSlice()
+	sort.Slice(actions, func(i, j int) bool { return actions[i] < actions[j] })
+	return json.Marshal(actions)
 }
 
 func (actionSet ActionSet) String() string {
@@ -91,6 +91,7 @@ func TestActionSetMarshalJSON(t *testing.T) {
 		expectErr      bool
 	}{
 		{NewActionSet(PutObjectAction), []byte(`["s3:PutObject"]`), false},
+		{NewActionSet(PutObjectAction, GetObjectAction, DeleteObjectAction), []byte(`["s3:DeleteObject","s3:GetObject","s3:PutObject"]`), false},
 		{NewActionSet(), nil, true},
 	}
 
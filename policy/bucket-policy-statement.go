@@ -27,7 +27,8 @@ import (
 type BPStatement struct {
 	SID          ID                  `json:"Sid,omitempty"`
 	Effect       Effect              `json:"Effect"`
-	Principal    Principal           `json:"Principal"`
+	Principal    Principal           `json:"Principal,omitzero"`
+	NotPrincipal Principal           `json:"NotPrincipal,omitzero"`
 	Actions      ActionSet           `json:"Action"`
 	NotActions   ActionSet           `json:"NotAction,omitempty"`
 	Resources    ResourceSet         `json:"Resource"`
@@ -35,10 +36,20 @@ type BPStatement struct {
 	Conditions   condition.Functions `json:"Condition,omitempty"`
 }
 
+// matchPrincipal - checks whether the statement applies to the given
+// account, i.e. it matches Principal or does not match NotPrincipal. Policy
+// variables in principals are replaced by their values in conditionValues.
+func (statement BPStatement) matchPrincipal(accountName string, conditionValues map[string][]string) bool {
+	if statement.NotPrincipal.IsValid() {
+		return !statement.NotPrincipal.match(accountName, conditionValues)
+	}
+	return statement.Principal.match(accountName, conditionValues)
+}
+
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (statement BPStatement) IsAllowed(args BucketPolicyArgs) bool {
 	check := func() bool {
-		if !statement.Principal.Match(args.AccountName) {
+		if !statement.matchPrincipal(args.AccountName, args.ConditionValues) {
 			return false
 		}
 
@@ -70,14 +81,34 @@ func (statement BPStatement) IsAllowed(args BucketPolicyArgs) bool {
 	return statement.Effect.IsAllowed(check())
 }
 
-// isValid - checks whether statement is valid or not.
+// isValid - checks whether statement is valid or not. The error names the
+// field at fault, see errorField(), unless no single field is to blame.
 func (statement BPStatement) isValid() error {
 	if !statement.Effect.IsValid() {
-		return Errorf("invalid Effect %v", statement.Effect)
+		return fieldErrorf("Effect", "invalid Effect %v", statement.Effect)
+	}
+
+	if statement.NotPrincipal.IsValid() {
+		if statement.Principal.IsValid() {
+			return fieldErrorf("NotPrincipal", "Principal and NotPrincipal cannot be specified in the same statement")
+		}
+		if statement.Effect != Deny {
+			return fieldErrorf("NotPrincipal", "NotPrincipal can only be used with Deny Effect")
+		}
+	} else if !statement.Principal.IsValid() {
+		return fieldErrorf("Principal", "invalid Principal %v", statement.Principal)
+	}
+
+	if err := statement.Principal.validateVariables(); err != nil {
+		return fieldErrorf("Principal", "%w", err)
+	}
+
+	if err := statement.NotPrincipal.validateVariables(); err != nil {
+		return fieldErrorf("NotPrincipal", "%w", err)
 	}
 
-	if !statement.Principal.IsValid() {
-		return Errorf("invalid Principal %v", statement.Principal)
+	if err := statement.Conditions.ValidateVariables(); err != nil {
+		return fieldErrorf("Condition", "%w", err)
 	}
 
 	if len(statement.Actions) == 0 && len(statement.NotActions) == 0 {
@@ -85,7 +116,7 @@ func (statement BPStatement) isValid() error {
 	}
 
 	if len(statement.Actions) > 0 && len(statement.NotActions) > 0 {
-		return Errorf("Action and NotAction cannot be specified in the same statement")
+		return fieldErrorf("NotAction", "Action and NotAction cannot be specified in the same statement")
 	}
 
 	if len(statement.Resources) == 0 && len(statement.NotResources) == 0 {
@@ -93,30 +124,30 @@ func (statement BPStatement) isValid() error {
 	}
 
 	if len(statement.Resources) > 0 && len(statement.NotResources) > 0 {
-		return Errorf("Resource and NotResource cannot be specified in the same statement")
+		return fieldErrorf("NotResource", "Resource and NotResource cannot be specified in the same statement")
 	}
 
 	for action := range statement.Actions {
 		if action.IsObjectAction() {
 			if len(statement.Resources) > 0 && !statement.Resources.ObjectResourceExists() {
-				return Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
+				return fieldErrorf("Resource", "unsupported Resource found %v for action %v", statement.Resources, action)
 			}
 			if len(statement.NotResources) > 0 && !statement.NotResources.ObjectResourceExists() {
-				return Errorf("unsupported Resource found %v for action %v", statement.NotResources, action)
+				return fieldErrorf("NotResource", "unsupported Resource found %v for action %v", statement.NotResources, action)
 			}
 		} else {
 			if len(statement.Resources) > 0 && !statement.Resources.BucketResourceExists() {
-				return Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
+				return fieldErrorf("Resource", "unsupported Resource found %v for action %v", statement.Resources, action)
 			}
 			if len(statement.NotResources) > 0 && !statement.NotResources.BucketResourceExists() {
-				return Errorf("unsupported Resource found %v for action %v", statement.NotResources, action)
+				return fieldErrorf("NotResource", "unsupported Resource found %v for action %v", statement.NotResources, action)
 			}
 		}
 
 		keys := statement.Conditions.Keys()
 		keyDiff := keys.Difference(IAMActionConditionKeyMap.Lookup(action))
 		if !keyDiff.IsEmpty() {
-			return Errorf("unsupported condition keys '%v' used for action '%v'", keyDiff, action)
+			return fieldErrorf("Condition", "unsupported condition keys '%v' used for action '%v'", keyDiff, action)
 		}
 	}
 
@@ -131,13 +162,13 @@ func (statement BPStatement) Validate(bucketName string) error {
 
 	if len(statement.Resources) > 0 {
 		if err := statement.Resources.ValidateBucket(bucketName); err != nil {
-			return err
+			return fieldErrorf("Resource", "%w", err)
 		}
 	}
 
 	if len(statement.NotResources) > 0 {
 		if err := statement.NotResources.ValidateBucket(bucketName); err != nil {
-			return err
+			return fieldErrorf("NotResource", "%w", err)
 		}
 	}
 
@@ -152,6 +183,9 @@ func (statement BPStatement) Equals(st BPStatement) bool {
 	if !statement.Principal.Equals(st.Principal) {
 		return false
 	}
+	if !statement.NotPrincipal.Equals(st.NotPrincipal) {
+		return false
+	}
 	if !statement.Actions.Equals(st.Actions) {
 		return false
 	}
@@ -176,6 +210,7 @@ func (statement BPStatement) Clone() BPStatement {
 		SID:          statement.SID,
 		Effect:       statement.Effect,
 		Principal:    statement.Principal.Clone(),
+		NotPrincipal: statement.NotPrincipal.Clone(),
 		Actions:      statement.Actions.Clone(),
 		NotActions:   statement.NotActions.Clone(),
 		Resources:    statement.Resources.Clone(),
@@ -208,6 +243,18 @@ func NewBPStatementWithNotAction(sid ID, effect Effect, principal Principal, not
 	}
 }
 
+// NewBPStatementWithNotPrincipal - creates new statement with NotPrincipal.
+func NewBPStatementWithNotPrincipal(sid ID, effect Effect, notPrincipal Principal, actions ActionSet, resources ResourceSet, conditions condition.Functions) BPStatement {
+	return BPStatement{
+		SID:          sid,
+		Effect:       effect,
+		NotPrincipal: notPrincipal,
+		Actions:      actions,
+		Resources:    resources,
+		Conditions:   conditions,
+	}
+}
+
 // NewBPStatementWithNotResource - creates new statement with NotResource.
 func NewBPStatementWithNotResource(sid ID, effect Effect, principal Principal, actions ActionSet, notResources ResourceSet, conditions condition.Functions) BPStatement {
 	return BPStatement{
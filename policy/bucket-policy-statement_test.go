@@ -369,6 +369,30 @@ func TestBPStatementIsValid(t *testing.T) {
 			NewResourceSet(NewResource("mybucket/myobject*")),
 			condition.NewFunctions(),
 		), false},
+		{NewBPStatementWithNotPrincipal("",
+			Deny,
+			NewPrincipal("Q3AM3UQ867SPQQA43P2F"),
+			NewActionSet(GetObjectAction, PutObjectAction),
+			NewResourceSet(NewResource("mybucket/myobject*")),
+			condition.NewFunctions(),
+		), false},
+		// NotPrincipal with Allow error.
+		{NewBPStatementWithNotPrincipal("",
+			Allow,
+			NewPrincipal("Q3AM3UQ867SPQQA43P2F"),
+			NewActionSet(GetObjectAction, PutObjectAction),
+			NewResourceSet(NewResource("mybucket/myobject*")),
+			condition.NewFunctions(),
+		), true},
+		// Principal and NotPrincipal error.
+		{BPStatement{
+			Effect:       Deny,
+			Principal:    NewPrincipal("*"),
+			NotPrincipal: NewPrincipal("Q3AM3UQ867SPQQA43P2F"),
+			Actions:      NewActionSet(GetObjectAction),
+			Resources:    NewResourceSet(NewResource("mybucket/myobject*")),
+			Conditions:   condition.NewFunctions(),
+		}, true},
 	}
 
 	for i, testCase := range testCases {
@@ -591,6 +615,69 @@ func TestBPStatementUnmarshalJSONAndValidate(t *testing.T) {
 	}
 }
 
+func TestBPStatementNotPrincipal(t *testing.T) {
+	data := []byte(`{
+    "Effect": "Deny",
+    "NotPrincipal": {"AWS": ["Q3AM3UQ867SPQQA43P2F"]},
+    "Action": "s3:GetObject",
+    "Resource": "arn:aws:s3:::mybucket/*"
+}`)
+	expectedStatement := NewBPStatementWithNotPrincipal("",
+		Deny,
+		NewPrincipal("Q3AM3UQ867SPQQA43P2F"),
+		NewActionSet(GetObjectAction),
+		NewResourceSet(NewResource("mybucket/*")),
+		condition.NewFunctions(),
+	)
+
+	var statement BPStatement
+	if err := json.Unmarshal(data, &statement); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	if err := statement.Validate("mybucket"); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	if !statement.Equals(expectedStatement) {
+		t.Fatalf("expected: %v, got: %v", expectedStatement, statement)
+	}
+	if statement.Equals(NewBPStatement("", Deny, NewPrincipal("Q3AM3UQ867SPQQA43P2F"), NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions())) {
+		t.Fatalf("statements with Principal and NotPrincipal must not be equal")
+	}
+
+	clone := statement.Clone()
+	clone.NotPrincipal.AWS.Add("other")
+	if statement.NotPrincipal.AWS.Contains("other") {
+		t.Fatalf("clone must not share NotPrincipal")
+	}
+
+	encoded, err := json.Marshal(statement)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	var decoded BPStatement
+	if err = json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	if !decoded.Equals(statement) || !decoded.Principal.IsZero() {
+		t.Fatalf("expected: %v, got: %v (%s)", statement, decoded, encoded)
+	}
+
+	testCases := []struct {
+		args           BucketPolicyArgs
+		expectedResult bool
+	}{
+		{BucketPolicyArgs{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true},
+		{BucketPolicyArgs{AccountName: "anybody", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false},
+		{BucketPolicyArgs{AccountName: "anybody", Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true},
+	}
+
+	for i, testCase := range testCases {
+		if result := statement.IsAllowed(testCase.args); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestBPStatementValidate(t *testing.T) {
 	case1Statement := NewBPStatement("",
 		Allow,
@@ -641,3 +728,47 @@ func TestBPStatementValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestBPStatementVariables(t *testing.T) {
+	testCases := []struct {
+		data           string
+		accountName    string
+		expectErr      bool
+		expectedResult bool
+	}{
+		{`{"Effect": "Allow", "Principal": {"AWS": "${aws:username}"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}`, "alice", false, true},
+		{`{"Effect": "Allow", "Principal": {"AWS": "${aws:username}"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}`, "bob", false, false},
+		{`{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/${aws:username}/*"}`, "bob", false, true},
+		{`{"Effect": "Allow", "Principal": {"AWS": "${aws:unknown}"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}`, "alice", true, false},
+		{`{"Effect": "Deny", "NotPrincipal": {"AWS": "${jwt:unknown}"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}`, "alice", true, false},
+	}
+
+	for i, testCase := range testCases {
+		var statement BPStatement
+		if err := json.Unmarshal([]byte(testCase.data), &statement); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		err := statement.Validate("mybucket")
+		expectErr := (err != nil)
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if expectErr {
+			if errorField(err) == "" {
+				t.Fatalf("case %v: expected invalid field", i+1)
+			}
+			continue
+		}
+
+		result := statement.IsAllowed(BucketPolicyArgs{
+			AccountName:     testCase.accountName,
+			Action:          GetObjectAction,
+			BucketName:      "mybucket",
+			ObjectName:      "alice/myobject",
+			ConditionValues: map[string][]string{"username": {"alice"}},
+		})
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
@@ -18,6 +18,7 @@
 package policy
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 )
@@ -169,14 +170,29 @@ func (policy BucketPolicy) Validate(bucketName string) error {
 func ParseBucketPolicyConfig(reader io.Reader, bucketName string) (*BucketPolicy, error) {
 	var policy BucketPolicy
 
-	decoder := json.NewDecoder(reader)
-	decoder.DisallowUnknownFields()
-	if err := decoder.Decode(&policy); err != nil {
+	data, err := io.ReadAll(reader)
+	if err != nil {
 		return nil, Errorf("%w", err)
 	}
 
-	err := policy.Validate(bucketName)
-	return &policy, err
+	checkStatement := func(data []byte) error {
+		var statement BPStatement
+		if err := json.Unmarshal(data, &statement); err != nil {
+			return err
+		}
+		return statement.Validate(bucketName)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&policy); err != nil {
+		return nil, locateError[BPStatement](data, err, checkStatement)
+	}
+
+	if err = policy.Validate(bucketName); err != nil {
+		return &policy, locateError[BPStatement](data, err, checkStatement)
+	}
+	return &policy, nil
 }
 
 // Equals returns true if the two policies are identical
@@ -0,0 +1,228 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"path"
+	"strings"
+
+	"github.com/minio/pkg/v3/wildcard"
+)
+
+// prefixNode - node of a byte trie over the literal prefixes of resource
+// patterns.
+type prefixNode struct {
+	children   map[byte]*prefixNode
+	statements []int
+}
+
+// resourceIndex - finds the statements whose resource patterns may match a
+// resource.
+type resourceIndex struct {
+	// any holds statements which have to be checked for every resource.
+	any  []int
+	root prefixNode
+}
+
+// literalPrefix - returns the part of the pattern before the first wildcard
+// or policy variable, which every matching resource starts with.
+func literalPrefix(pattern string) string {
+	if i := strings.IndexAny(pattern, "*?$"); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
+
+func (idx *resourceIndex) add(i int, statement *Statement) {
+	if len(statement.Resources) == 0 || len(statement.NotResources) > 0 ||
+		statement.isKMS() || statement.isAdmin() || statement.isSTS() {
+		// Resources are ignored or the statement matches resources not
+		// matching its patterns, see IsAllowedPtr().
+		idx.any = append(idx.any, i)
+		return
+	}
+
+	for resource := range statement.Resources {
+		node := &idx.root
+		prefix := literalPrefix(resource.Pattern)
+		for j := 0; j < len(prefix); j++ {
+			child, ok := node.children[prefix[j]]
+			if !ok {
+				if node.children == nil {
+					node.children = make(map[byte]*prefixNode)
+				}
+				child = &prefixNode{}
+				node.children[prefix[j]] = child
+			}
+			node = child
+		}
+		node.statements = append(node.statements, i)
+	}
+}
+
+// visit - calls fn for every statement which may match the resource until fn
+// returns true. A statement may be visited more than once.
+func (idx *resourceIndex) visit(resource string, fn func(i int) bool) bool {
+	for _, i := range idx.any {
+		if fn(i) {
+			return true
+		}
+	}
+
+	walk := func(s string) bool {
+		node := &idx.root
+		for j := 0; ; j++ {
+			for _, i := range node.statements {
+				if fn(i) {
+					return true
+				}
+			}
+			if j == len(s) {
+				return false
+			}
+			if node = node.children[s[j]]; node == nil {
+				return false
+			}
+		}
+	}
+
+	if walk(resource) {
+		return true
+	}
+	// Resource patterns also match the cleaned resource, see Resource.Match().
+	if cleaned := path.Clean(resource); cleaned != resource && cleaned != "." {
+		return walk(cleaned)
+	}
+	return false
+}
+
+// statementIndex - finds the statements which may match a request.
+type statementIndex struct {
+	byAction map[Action]*resourceIndex
+	// generic holds statements with wildcard actions or NotAction.
+	generic resourceIndex
+}
+
+func (idx *statementIndex) add(i int, statement *Statement) {
+	if len(statement.Actions) == 0 {
+		idx.generic.add(i, statement)
+		return
+	}
+	for action := range statement.Actions {
+		if wildcard.Has(string(action)) {
+			idx.generic.add(i, statement)
+			return
+		}
+	}
+
+	addAction := func(action Action) {
+		ridx, ok := idx.byAction[action]
+		if !ok {
+			ridx = &resourceIndex{}
+			idx.byAction[action] = ridx
+		}
+		ridx.add(i, statement)
+	}
+	for action := range statement.Actions {
+		addAction(action)
+		// GetObjectVersion implicitly enables GetObject, see ActionSet.Match().
+		if action == GetObjectVersionAction && !statement.Actions.Contains(GetObjectAction) {
+			addAction(GetObjectAction)
+		}
+	}
+}
+
+func (idx *statementIndex) visit(action Action, resource string, fn func(i int) bool) bool {
+	if ridx, ok := idx.byAction[action]; ok && ridx.visit(resource, fn) {
+		return true
+	}
+	return idx.generic.visit(resource, fn)
+}
+
+// CompiledPolicies - immutable index over a set of policies which decides
+// requests the same way as IsAllowedSerial() while only evaluating the
+// statements whose actions and resource prefixes may match the request.
+type CompiledPolicies struct {
+	statements  []Statement
+	deny        statementIndex
+	allow       statementIndex
+	numPolicies int
+}
+
+// Compile - builds the compiled form of the given policies. The policies are
+// cloned, later changes to them are not reflected.
+func Compile(policies []Policy) *CompiledPolicies {
+	c := &CompiledPolicies{
+		deny:        statementIndex{byAction: make(map[Action]*resourceIndex)},
+		allow:       statementIndex{byAction: make(map[Action]*resourceIndex)},
+		numPolicies: len(policies),
+	}
+
+	for _, policy := range policies {
+		for _, statement := range policy.Statements {
+			c.statements = append(c.statements, statement.Clone())
+		}
+	}
+
+	for i := range c.statements {
+		statement := &c.statements[i]
+		switch statement.Effect {
+		case Deny:
+			c.deny.add(i, statement)
+		case Allow:
+			c.allow.add(i, statement)
+		}
+	}
+
+	return c
+}
+
+// Decide - decides whether the given args is allowed by the compiled
+// policies, combining their decisions like IsAllowedSerial().
+func (c *CompiledPolicies) Decide(args *Args) Decision {
+	if c.numPolicies == 0 {
+		return NoDecision
+	}
+
+	resource := resourceName(args.BucketName, args.ObjectName)
+	denied := c.deny.visit(args.Action, resource, func(i int) bool {
+		return !c.statements[i].IsAllowedPtr(args)
+	})
+	if denied {
+		return DenyDecision
+	}
+
+	if args.DenyOnly || args.IsOwner {
+		return AllowDecision
+	}
+
+	allowed := c.allow.visit(args.Action, resource, func(i int) bool {
+		return c.statements[i].IsAllowedPtr(args)
+	})
+	if allowed {
+		return AllowDecision
+	}
+
+	return NoDecision
+}
+
+// IsAllowed - checks whether the given args is allowed by the compiled
+// policies, returns the same result as IsAllowedSerial().
+func (c *CompiledPolicies) IsAllowed(args Args) bool {
+	return c.Decide(&args) == AllowDecision
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+func TestCompiledPoliciesIsAllowed(t *testing.T) {
+	policiesData := []string{
+		`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:ListBucket"], "Resource": ["arn:aws:s3:::mybucket"]},
+    {"Effect": "Allow", "Action": ["s3:GetObjectVersion", "s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/data/*"]}
+]}`,
+		`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:Get*"], "Resource": ["arn:aws:s3:::public*"]},
+    {"Effect": "Deny", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::public/secret/*"]}
+]}`,
+		`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:PutObject"], "Resource": ["arn:aws:s3:::home/${aws:username}/*"]},
+    {"Effect": "Deny", "Action": ["s3:DeleteObject"], "NotResource": ["arn:aws:s3:::home/*"]}
+]}`,
+		`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "NotAction": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::scratch/?a*"]},
+    {"Effect": "Allow", "Action": ["admin:ServerInfo"]},
+    {"Effect": "Deny", "Action": ["admin:CreateUser"]},
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::ip/*"],
+     "Condition": {"IpAddress": {"aws:SourceIp": "192.168.1.0/24"}}}
+]}`,
+		`{"Version": "2012-10-17", "Statement": []}`,
+	}
+
+	var policies []Policy
+	for _, data := range policiesData {
+		policy, err := ParseConfig(strings.NewReader(data))
+		if err != nil {
+			t.Fatalf("unexpected error. %v\n", err)
+		}
+		policies = append(policies, *policy)
+	}
+
+	actions := []Action{
+		ListBucketAction, GetObjectAction, GetObjectVersionAction, PutObjectAction,
+		DeleteObjectAction, GetBucketLocationAction, ServerInfoAdminAction,
+		CreateUserAdminAction, CreateServiceAccountAdminAction,
+	}
+	resources := [][2]string{
+		{"mybucket", ""}, {"mybucket", "data/a"}, {"mybucket", "/data/a"}, {"mybucket", "data//a"},
+		{"mybucket", "other"}, {"public", ""}, {"public", "secret/a"}, {"publicity", "a"},
+		{"home", "alice/a"}, {"home", "bob/a"}, {"other", "a"}, {"scratch", "xa"},
+		{"scratch", "xab"}, {"ip", "a"}, {"", ""},
+	}
+	conditions := []map[string][]string{
+		nil,
+		{"username": {"alice"}, "SourceIp": {"192.168.1.10"}},
+	}
+
+	for n := 0; n <= len(policies); n++ {
+		compiled := Compile(policies[:n])
+		for _, action := range actions {
+			for _, resource := range resources {
+				for _, conds := range conditions {
+					for _, flags := range [][2]bool{{false, false}, {true, false}, {false, true}} {
+						args := Args{
+							AccountName:     "alice",
+							Action:          action,
+							BucketName:      resource[0],
+							ObjectName:      resource[1],
+							ConditionValues: conds,
+							IsOwner:         flags[0],
+							DenyOnly:        flags[1],
+						}
+						expected := IsAllowedSerial(policies[:n], args)
+						if result := compiled.IsAllowed(args); result != expected {
+							t.Fatalf("%v policies: %+v: expected: %v, got: %v", n, args, expected, result)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCompiledPoliciesImmutable(t *testing.T) {
+	policy := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("*")), condition.NewFunctions()),
+		},
+	}
+	compiled := Compile([]Policy{policy})
+	policy.Statements[0].Actions.Add(PutObjectAction)
+
+	args := Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"}
+	if compiled.IsAllowed(args) {
+		t.Fatalf("compiled policies must not reflect later changes")
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/pkg/v3/wildcard"
+)
+
+// arnComponents - number of colon separated components of an ARN, i.e.
+// arn:partition:service:region:account-id:resource
+const arnComponents = 6
+
+// arnMatch - matches the ARN with the ARN pattern component by component,
+// each pattern component may contain '*' and '?' wildcards which do not
+// match across components except in the last (resource) component.
+func arnMatch(pattern, arn string) bool {
+	patterns := strings.SplitN(pattern, ":", arnComponents)
+	components := strings.SplitN(arn, ":", arnComponents)
+	if len(patterns) != arnComponents || len(components) != arnComponents {
+		return false
+	}
+
+	for i := range patterns {
+		if !wildcard.Match(patterns[i], components[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// arnFunc - ARN function. It checks whether an ARN by Key in given values
+// map matches any of the ARN patterns in condition values.
+// For example,
+//   - if values = ["arn:aws:iam::*:role/admin-*"], at evaluate() it returns
+//     whether ARN in value map for Key matches the pattern.
+type arnFunc struct {
+	stringFunc
+}
+
+func (f arnFunc) eval(values map[string][]string) bool {
+	rvalues := getValuesByKey(values, f.k)
+	fvalues := f.values.ApplyFunc(substitute(values))
+	for _, v := range rvalues {
+		matched := !fvalues.FuncMatch(arnMatch, v).IsEmpty()
+		if f.n.qualifier == forAllValues {
+			if !matched {
+				return false
+			}
+		} else if matched {
+			return true
+		}
+	}
+	return f.n.qualifier == forAllValues
+}
+
+// evaluate() - evaluates to check whether ARN by Key in given values matches
+// any of the ARN patterns in condition values.
+func (f arnFunc) evaluate(values map[string][]string) bool {
+	result := f.eval(values)
+	if f.negate {
+		return !result
+	}
+	return result
+}
+
+func (f arnFunc) clone() Function {
+	return &arnFunc{stringFunc: f.copy()}
+}
+
+func newArnFunc(n string, key Key, values ValueSet, qualifier string, negate bool) (Function, error) {
+	valueStrings, err := valuesToStringSlice(n, values)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range valueStrings {
+		if len(strings.SplitN(s, ":", arnComponents)) != arnComponents {
+			return nil, fmt.Errorf("invalid ARN '%v' for %v condition", s, n)
+		}
+	}
+
+	if _, found := qualifiers[qualifier]; qualifier != "" && !found {
+		return nil, fmt.Errorf("set qualifier must be %v or %v", forAllValues, forAnyValue)
+	}
+
+	return &arnFunc{stringFunc{
+		n:      name{name: n, qualifier: qualifier},
+		k:      key,
+		values: set.CreateStringSet(valueStrings...),
+		negate: negate,
+	}}, nil
+}
+
+func arnValueSet(values []string) ValueSet {
+	vset := NewValueSet()
+	for _, value := range values {
+		vset.Add(NewStringValue(value))
+	}
+	return vset
+}
+
+// newArnEqualsFunc - returns new ArnEquals function.
+func newArnEqualsFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newArnFunc(arnEquals, key, values, qualifier, false)
+}
+
+// NewArnEqualsFunc - returns new ArnEquals function.
+func NewArnEqualsFunc(qualifier string, key Key, values ...string) (Function, error) {
+	return newArnEqualsFunc(key, arnValueSet(values), qualifier)
+}
+
+// newArnNotEqualsFunc - returns new ArnNotEquals function.
+func newArnNotEqualsFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newArnFunc(arnNotEquals, key, values, qualifier, true)
+}
+
+// NewArnNotEqualsFunc - returns new ArnNotEquals function.
+func NewArnNotEqualsFunc(qualifier string, key Key, values ...string) (Function, error) {
+	return newArnNotEqualsFunc(key, arnValueSet(values), qualifier)
+}
+
+// newArnLikeFunc - returns new ArnLike function.
+func newArnLikeFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newArnFunc(arnLike, key, values, qualifier, false)
+}
+
+// NewArnLikeFunc - returns new ArnLike function.
+func NewArnLikeFunc(qualifier string, key Key, values ...string) (Function, error) {
+	return newArnLikeFunc(key, arnValueSet(values), qualifier)
+}
+
+// newArnNotLikeFunc - returns new ArnNotLike function.
+func newArnNotLikeFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newArnFunc(arnNotLike, key, values, qualifier, true)
+}
+
+// NewArnNotLikeFunc - returns new ArnNotLike function.
+func NewArnNotLikeFunc(qualifier string, key Key, values ...string) (Function, error) {
+	return newArnNotLikeFunc(key, arnValueSet(values), qualifier)
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestArnMatch(t *testing.T) {
+	testCases := []struct {
+		pattern        string
+		arn            string
+		expectedResult bool
+	}{
+		{"arn:aws:iam::123456789012:role/admin", "arn:aws:iam::123456789012:role/admin", true},
+		{"arn:aws:iam::123456789012:role/admin", "arn:aws:iam::123456789012:role/admins", false},
+		{"arn:aws:iam::*:role/admin-*", "arn:aws:iam::123456789012:role/admin-east", true},
+		{"arn:aws:iam::*:role/admin-*", "arn:aws:iam::123456789012:user/admin-east", false},
+		{"arn:aws:s3:::mybucket/*", "arn:aws:s3:::mybucket/a:b:c", true},
+		{"arn:minio:sqs::?:webhook", "arn:minio:sqs::1:webhook", true},
+		{"arn:minio:sqs::?:webhook", "arn:minio:sqs::12:webhook", false},
+		// wildcards do not match across components.
+		{"arn:*:role/admin", "arn:aws:iam::123456789012:role/admin", false},
+		{"arn:aws:*:*:*:*", "arn:aws:iam::123456789012:role/admin", true},
+		{"arn:aws:*:*:*:*", "arn:aws:iam:role/admin", false},
+		{"arn:aws:*:*:*:*", "not-an-arn", false},
+	}
+
+	for i, testCase := range testCases {
+		if result := arnMatch(testCase.pattern, testCase.arn); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestArnFuncEvaluate(t *testing.T) {
+	case1Function, err := NewArnEqualsFunc("", AWSSourceArn.ToKey(), "arn:aws:s3:::mybucket")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case2Function, err := NewArnLikeFunc("", AWSPrincipalArn.ToKey(), "arn:aws:iam::*:role/admin-*", "arn:aws:iam::*:user/root")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case3Function, err := NewArnNotLikeFunc("", AWSPrincipalArn.ToKey(), "arn:aws:iam::*:role/admin-*")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case4Function, err := NewArnNotEqualsFunc("", AWSSourceArn.ToKey(), "arn:aws:s3:::mybucket")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case5Function, err := NewArnLikeFunc(forAllValues, AWSPrincipalArn.ToKey(), "arn:aws:iam::*:role/*")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case6Function, err := NewArnLikeFunc(forAnyValue, AWSPrincipalArn.ToKey(), "arn:aws:iam::*:role/*")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		function       Function
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{case1Function, map[string][]string{"SourceArn": {"arn:aws:s3:::mybucket"}}, true},
+		{case1Function, map[string][]string{"SourceArn": {"arn:aws:s3:::yourbucket"}}, false},
+		{case1Function, map[string][]string{}, false},
+
+		{case2Function, map[string][]string{"PrincipalArn": {"arn:aws:iam::123456789012:role/admin-east"}}, true},
+		{case2Function, map[string][]string{"PrincipalArn": {"arn:aws:iam::123456789012:user/root"}}, true},
+		{case2Function, map[string][]string{"PrincipalArn": {"arn:aws:iam::123456789012:role/dev"}}, false},
+
+		{case3Function, map[string][]string{"PrincipalArn": {"arn:aws:iam::123456789012:role/admin-east"}}, false},
+		{case3Function, map[string][]string{"PrincipalArn": {"arn:aws:iam::123456789012:role/dev"}}, true},
+		{case3Function, map[string][]string{}, true},
+
+		{case4Function, map[string][]string{"SourceArn": {"arn:aws:s3:::mybucket"}}, false},
+		{case4Function, map[string][]string{"SourceArn": {"arn:aws:s3:::yourbucket"}}, true},
+
+		{case5Function, map[string][]string{"PrincipalArn": {"arn:aws:iam::1:role/a", "arn:aws:iam::1:role/b"}}, true},
+		{case5Function, map[string][]string{"PrincipalArn": {"arn:aws:iam::1:role/a", "arn:aws:iam::1:user/b"}}, false},
+		{case5Function, map[string][]string{}, true},
+
+		{case6Function, map[string][]string{"PrincipalArn": {"arn:aws:iam::1:role/a", "arn:aws:iam::1:user/b"}}, true},
+		{case6Function, map[string][]string{"PrincipalArn": {"arn:aws:iam::1:user/b"}}, false},
+		{case6Function, map[string][]string{}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.function.evaluate(testCase.values)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestNewArnFunc(t *testing.T) {
+	testCases := []struct {
+		qualifier string
+		values    []string
+		expectErr bool
+	}{
+		{"", []string{"arn:aws:iam::123456789012:role/admin"}, false},
+		{forAnyValue, []string{"arn:aws:iam::*:role/*"}, false},
+		// not an ARN.
+		{"", []string{"mybucket"}, true},
+		// missing components.
+		{"", []string{"arn:aws:iam:role/admin"}, true},
+		// invalid qualifier.
+		{"ForSomeValues", []string{"arn:aws:iam::123456789012:role/admin"}, true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := NewArnLikeFunc(testCase.qualifier, AWSPrincipalArn.ToKey(), testCase.values...)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+	}
+}
+
+func TestArnFuncJSON(t *testing.T) {
+	data := []byte(`{"ArnLike": {"aws:PrincipalArn": ["arn:aws:iam::*:role/admin-*"]}, "ForAnyValue:ArnNotEquals": {"aws:SourceArn": "arn:aws:s3:::mybucket"}}`)
+
+	var functions Functions
+	if err := json.Unmarshal(data, &functions); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	if len(functions) != 2 {
+		t.Fatalf("expected: 2 functions, got: %v", len(functions))
+	}
+
+	encoded, err := json.Marshal(functions)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	var decoded Functions
+	if err = json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	if !decoded.Equals(functions) {
+		t.Fatalf("expected: %v, got: %v", functions, decoded)
+	}
+
+	values := map[string][]string{
+		"PrincipalArn": {"arn:aws:iam::123456789012:role/admin-east"},
+		"SourceArn":    {"arn:aws:s3:::otherbucket"},
+	}
+	if !decoded.Evaluate(values) {
+		t.Fatalf("expected functions to match %v", values)
+	}
+}
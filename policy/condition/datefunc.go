@@ -28,14 +28,36 @@ type dateFunc struct {
 	k     Key
 	value time.Time
 	c     condition
+	// variable holds the policy variable whose value is compared, such as
+	// "${jwt:exp}", value is unused if set.
+	variable string
 }
 
+// evaluate() - evaluates the condition for the first request value, or for
+// all of them if the function has a qualifier.
 func (f dateFunc) evaluate(values map[string][]string) bool {
+	if f.variable != "" {
+		v, err := time.Parse(time.RFC3339, Substitute(f.variable, values))
+		if err != nil {
+			// The variable has no value or it is not a time.
+			return false
+		}
+		f.value, f.variable = v, ""
+	}
+
 	rvalues := getValuesByKey(values, f.k)
+	if f.n.qualifier != "" {
+		return matchValues(f.n.qualifier, rvalues, f.match)
+	}
 	if len(rvalues) == 0 {
 		return false
 	}
-	t, err := time.Parse(time.RFC3339, rvalues[0])
+	return f.match(rvalues[0])
+}
+
+// match - returns whether the given request value satisfies the condition.
+func (f dateFunc) match(s string) bool {
+	t, err := time.Parse(time.RFC3339, s)
 	if err != nil {
 		return false
 	}
@@ -67,8 +89,16 @@ func (f dateFunc) name() name {
 	return f.n
 }
 
+// valueString - returns the condition value as string.
+func (f dateFunc) valueString() string {
+	if f.variable != "" {
+		return f.variable
+	}
+	return f.value.Format(time.RFC3339)
+}
+
 func (f dateFunc) String() string {
-	return fmt.Sprintf("%v:%v:%v", f.n, f.k, f.value.Format(time.RFC3339))
+	return fmt.Sprintf("%v:%v:%v", f.n, f.k, f.valueString())
 }
 
 func (f dateFunc) toMap() map[Key]ValueSet {
@@ -77,7 +107,7 @@ func (f dateFunc) toMap() map[Key]ValueSet {
 	}
 
 	values := NewValueSet()
-	values.Add(NewStringValue(f.value.Format(time.RFC3339)))
+	values.Add(NewStringValue(f.valueString()))
 
 	return map[Key]ValueSet{
 		f.k: values,
@@ -86,10 +116,11 @@ func (f dateFunc) toMap() map[Key]ValueSet {
 
 func (f dateFunc) clone() Function {
 	return &dateFunc{
-		n:     f.n,
-		k:     f.k,
-		value: f.value,
-		c:     f.c,
+		n:        f.n,
+		k:        f.k,
+		value:    f.value,
+		c:        f.c,
+		variable: f.variable,
 	}
 }
 
@@ -116,14 +147,23 @@ func valueToTime(n string, values ValueSet) (v time.Time, err error) {
 	return v, nil
 }
 
-func newDateFunc(n string, key Key, values ValueSet, cond condition) (Function, error) {
+func newDateFunc(n string, key Key, values ValueSet, qualifier string, cond condition) (Function, error) {
+	if variable, ok := variableValue(values); ok {
+		return &dateFunc{
+			n:        name{qualifier: qualifier, name: n},
+			k:        key,
+			c:        cond,
+			variable: variable,
+		}, nil
+	}
+
 	v, err := valueToTime(n, values)
 	if err != nil {
 		return nil, err
 	}
 
 	return &dateFunc{
-		n:     name{name: n},
+		n:     name{qualifier: qualifier, name: n},
 		k:     key,
 		value: v,
 		c:     cond,
@@ -131,8 +171,8 @@ func newDateFunc(n string, key Key, values ValueSet, cond condition) (Function,
 }
 
 // newDateEqualsFunc - returns new DateEquals function.
-func newDateEqualsFunc(key Key, values ValueSet, _ string) (Function, error) {
-	return newDateFunc(dateEquals, key, values, equals)
+func newDateEqualsFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newDateFunc(dateEquals, key, values, qualifier, equals)
 }
 
 // NewDateEqualsFunc - returns new DateEquals function.
@@ -141,8 +181,8 @@ func NewDateEqualsFunc(key Key, value time.Time) (Function, error) {
 }
 
 // newDateNotEqualsFunc - returns new DateNotEquals function.
-func newDateNotEqualsFunc(key Key, values ValueSet, _ string) (Function, error) {
-	return newDateFunc(dateNotEquals, key, values, notEquals)
+func newDateNotEqualsFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newDateFunc(dateNotEquals, key, values, qualifier, notEquals)
 }
 
 // NewDateNotEqualsFunc - returns new DateNotEquals function.
@@ -151,8 +191,8 @@ func NewDateNotEqualsFunc(key Key, value time.Time) (Function, error) {
 }
 
 // newDateGreaterThanFunc - returns new DateGreaterThan function.
-func newDateGreaterThanFunc(key Key, values ValueSet, _ string) (Function, error) {
-	return newDateFunc(dateGreaterThan, key, values, greaterThan)
+func newDateGreaterThanFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newDateFunc(dateGreaterThan, key, values, qualifier, greaterThan)
 }
 
 // NewDateGreaterThanFunc - returns new DateGreaterThan function.
@@ -161,8 +201,8 @@ func NewDateGreaterThanFunc(key Key, value time.Time) (Function, error) {
 }
 
 // newDateGreaterThanEqualsFunc - returns new DateGreaterThanEquals function.
-func newDateGreaterThanEqualsFunc(key Key, values ValueSet, _ string) (Function, error) {
-	return newDateFunc(dateGreaterThanEquals, key, values, greaterThanEquals)
+func newDateGreaterThanEqualsFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newDateFunc(dateGreaterThanEquals, key, values, qualifier, greaterThanEquals)
 }
 
 // NewDateGreaterThanEqualsFunc - returns new DateGreaterThanEquals function.
@@ -171,8 +211,8 @@ func NewDateGreaterThanEqualsFunc(key Key, value time.Time) (Function, error) {
 }
 
 // newDateLessThanFunc - returns new DateLessThan function.
-func newDateLessThanFunc(key Key, values ValueSet, _ string) (Function, error) {
-	return newDateFunc(dateLessThan, key, values, lessThan)
+func newDateLessThanFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newDateFunc(dateLessThan, key, values, qualifier, lessThan)
 }
 
 // NewDateLessThanFunc - returns new DateLessThan function.
@@ -181,8 +221,8 @@ func NewDateLessThanFunc(key Key, value time.Time) (Function, error) {
 }
 
 // newDateLessThanEqualsFunc - returns new DateLessThanEquals function.
-func newDateLessThanEqualsFunc(key Key, values ValueSet, _ string) (Function, error) {
-	return newDateFunc(dateLessThanEquals, key, values, lessThanEquals)
+func newDateLessThanEqualsFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newDateFunc(dateLessThanEquals, key, values, qualifier, lessThanEquals)
 }
 
 // NewDateLessThanEqualsFunc - returns new DateLessThanEquals function.
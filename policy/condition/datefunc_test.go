@@ -18,6 +18,7 @@
 package condition
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 	"time"
@@ -336,3 +337,40 @@ func TestDateFuncClone(t *testing.T) {
 		}
 	}
 }
+
+func TestDateFuncQualifier(t *testing.T) {
+	testCases := []struct {
+		data           string
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{`{"ForAnyValue:DateLessThan":{"aws:CurrentTime":["2026-01-01T00:00:00Z"]}}`, map[string][]string{"CurrentTime": {"2027-01-01T00:00:00Z", "2025-01-01T00:00:00Z"}}, true},
+		{`{"ForAnyValue:DateLessThan":{"aws:CurrentTime":["2026-01-01T00:00:00Z"]}}`, map[string][]string{"CurrentTime": {"2027-01-01T00:00:00Z"}}, false},
+		{`{"ForAnyValue:DateLessThan":{"aws:CurrentTime":["2026-01-01T00:00:00Z"]}}`, map[string][]string{}, false},
+		{`{"ForAllValues:DateLessThan":{"aws:CurrentTime":["2026-01-01T00:00:00Z"]}}`, map[string][]string{"CurrentTime": {"2024-01-01T00:00:00Z", "2025-01-01T00:00:00Z"}}, true},
+		{`{"ForAllValues:DateLessThan":{"aws:CurrentTime":["2026-01-01T00:00:00Z"]}}`, map[string][]string{"CurrentTime": {"2027-01-01T00:00:00Z", "2025-01-01T00:00:00Z"}}, false},
+		{`{"ForAllValues:DateLessThan":{"aws:CurrentTime":["2026-01-01T00:00:00Z"]}}`, map[string][]string{"CurrentTime": {"yesterday"}}, false},
+		{`{"ForAllValues:DateLessThan":{"aws:CurrentTime":["2026-01-01T00:00:00Z"]}}`, map[string][]string{}, true},
+		// Without a qualifier only the first value is checked.
+		{`{"DateLessThan":{"aws:CurrentTime":["2026-01-01T00:00:00Z"]}}`, map[string][]string{"CurrentTime": {"2027-01-01T00:00:00Z", "2025-01-01T00:00:00Z"}}, false},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		if err := json.Unmarshal([]byte(testCase.data), &functions); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+
+		if result := functions.Evaluate(testCase.values); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+
+		data, err := json.Marshal(functions)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if string(data) != testCase.data {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.data, string(data))
+		}
+	}
+}
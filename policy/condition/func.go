@@ -55,6 +55,23 @@ type Function interface {
 	clone() Function
 }
 
+// matchValues - returns whether the request values match according to the
+// qualifier: for ForAllValues every value has to match, which is true if
+// there are no values, otherwise at least one value has to match.
+func matchValues(qualifier string, rvalues []string, match func(string) bool) bool {
+	for _, v := range rvalues {
+		matched := match(v)
+		if qualifier == forAllValues {
+			if !matched {
+				return false
+			}
+		} else if matched {
+			return true
+		}
+	}
+	return qualifier == forAllValues
+}
+
 // Functions - list of functions.
 type Functions []Function
 
@@ -70,6 +87,58 @@ func (functions Functions) Evaluate(values map[string][]string) bool {
 	return true
 }
 
+// Description - describes a condition function as written in a policy.
+type Description struct {
+	Name   string   `json:"name"`
+	Key    string   `json:"key"`
+	Values []string `json:"values"`
+}
+
+func describe(f Function) Description {
+	d := Description{
+		Name: f.name().String(),
+		Key:  f.key().String(),
+	}
+	for _, values := range f.toMap() {
+		for value := range values {
+			d.Values = append(d.Values, value.String())
+		}
+	}
+	sort.Strings(d.Values)
+	return d
+}
+
+// Describe - returns description of all functions in the order they are evaluated.
+func (functions Functions) Describe() []Description {
+	descriptions := make([]Description, 0, len(functions))
+	for _, f := range functions {
+		descriptions = append(descriptions, describe(f))
+	}
+	return descriptions
+}
+
+// Result - outcome of evaluating a single condition function.
+type Result struct {
+	Description
+	RequestValues []string `json:"requestValues"`
+	Matched       bool     `json:"matched"`
+}
+
+// Explain - evaluates every function with given values map and returns the
+// outcome of each one. Unlike Evaluate, it does not stop at the first failing
+// function so that all reasons for a mismatch are reported.
+func (functions Functions) Explain(values map[string][]string) []Result {
+	results := make([]Result, 0, len(functions))
+	for _, f := range functions {
+		results = append(results, Result{
+			Description:   describe(f),
+			RequestValues: getValuesByKey(values, f.key()),
+			Matched:       f.evaluate(values),
+		})
+	}
+	return results
+}
+
 // Keys - returns list of keys used in all functions.
 func (functions Functions) Keys() KeySet {
 	keySet := NewKeySet()
@@ -140,33 +209,56 @@ func (functions Functions) String() string {
 }
 
 var conditionFuncMap = map[string]func(Key, ValueSet, string) (Function, error){
-	stringEquals:               newStringEqualsFunc,
-	stringNotEquals:            newStringNotEqualsFunc,
-	stringEqualsIgnoreCase:     newStringEqualsIgnoreCaseFunc,
-	stringNotEqualsIgnoreCase:  newStringNotEqualsIgnoreCaseFunc,
-	binaryEquals:               newBinaryEqualsFunc,
-	stringLike:                 newStringLikeFunc,
-	stringNotLike:              newStringNotLikeFunc,
-	ipAddress:                  newIPAddressFunc,
-	notIPAddress:               newNotIPAddressFunc,
-	null:                       newNullFunc,
-	boolean:                    newBooleanFunc,
-	numericEquals:              newNumericEqualsFunc,
-	numericNotEquals:           newNumericNotEqualsFunc,
-	numericLessThan:            newNumericLessThanFunc,
-	numericLessThanEquals:      newNumericLessThanEqualsFunc,
-	numericGreaterThan:         newNumericGreaterThanFunc,
-	numericGreaterThanIfExists: newNumericGreaterThanIfExistsFunc,
-	numericGreaterThanEquals:   newNumericGreaterThanEqualsFunc,
-	dateEquals:                 newDateEqualsFunc,
-	dateNotEquals:              newDateNotEqualsFunc,
-	dateLessThan:               newDateLessThanFunc,
-	dateLessThanEquals:         newDateLessThanEqualsFunc,
-	dateGreaterThan:            newDateGreaterThanFunc,
-	dateGreaterThanEquals:      newDateGreaterThanEqualsFunc,
+	stringEquals:              newStringEqualsFunc,
+	stringNotEquals:           newStringNotEqualsFunc,
+	stringEqualsIgnoreCase:    newStringEqualsIgnoreCaseFunc,
+	stringNotEqualsIgnoreCase: newStringNotEqualsIgnoreCaseFunc,
+	binaryEquals:              newBinaryEqualsFunc,
+	stringLike:                newStringLikeFunc,
+	stringNotLike:             newStringNotLikeFunc,
+	ipAddress:                 newIPAddressFunc,
+	notIPAddress:              newNotIPAddressFunc,
+	null:                      newNullFunc,
+	boolean:                   newBooleanFunc,
+	numericEquals:             newNumericEqualsFunc,
+	numericNotEquals:          newNumericNotEqualsFunc,
+	numericLessThan:           newNumericLessThanFunc,
+	numericLessThanEquals:     newNumericLessThanEqualsFunc,
+	numericGreaterThan:        newNumericGreaterThanFunc,
+	numericGreaterThanEquals:  newNumericGreaterThanEqualsFunc,
+	dateEquals:                newDateEqualsFunc,
+	dateNotEquals:             newDateNotEqualsFunc,
+	dateLessThan:              newDateLessThanFunc,
+	dateLessThanEquals:        newDateLessThanEqualsFunc,
+	dateGreaterThan:           newDateGreaterThanFunc,
+	dateGreaterThanEquals:     newDateGreaterThanEqualsFunc,
+	arnEquals:                 newArnEqualsFunc,
+	arnNotEquals:              newArnNotEqualsFunc,
+	arnLike:                   newArnLikeFunc,
+	arnNotLike:                newArnNotLikeFunc,
 	// Add new conditions here.
 }
 
+// newFunction - returns new condition function of given name, key and values.
+func newFunction(n name, key Key, values ValueSet) (Function, error) {
+	var f Function
+	var err error
+	if fn, ok := conditionFuncMap[n.name]; ok {
+		f, err = fn(key, values, n.qualifier)
+	} else if newOperator, ok := lookupOperator(n.name); ok {
+		f, err = newOperatorFunc(n, key, values, newOperator)
+	} else {
+		return nil, fmt.Errorf("condition %v is not handled", n)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if n.ifExists {
+		f = &ifExistsFunc{f}
+	}
+	return f, nil
+}
+
 // UnmarshalJSON - decodes JSON data to Functions.
 func (functions *Functions) UnmarshalJSON(data []byte) error {
 	// As string kind, int kind then json.Unmarshaler is checked at
@@ -198,12 +290,7 @@ func (functions *Functions) UnmarshalJSON(data []byte) error {
 				return err
 			}
 
-			fn, ok := conditionFuncMap[n.name]
-			if !ok {
-				return fmt.Errorf("condition %v is not handled", n)
-			}
-
-			f, err := fn(key, values, n.qualifier)
+			f, err := newFunction(n, key, values)
 			if err != nil {
 				return err
 			}
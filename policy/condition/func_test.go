@@ -361,3 +361,62 @@ func TestFunctionsUnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestFunctionsExplain(t *testing.T) {
+	func1, err := newIPAddressFunc(AWSSourceIP.ToKey(), NewValueSet(NewStringValue("192.168.1.0/24")), "")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	func2, err := newStringEqualsFunc(S3Prefix.ToKey(), NewValueSet(NewStringValue("home/"), NewStringValue("")), "")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	functions := NewFunctions(func1, func2)
+
+	testCases := []struct {
+		values          map[string][]string
+		expectedMatched []bool
+	}{
+		{map[string][]string{"SourceIp": {"192.168.1.10"}, "prefix": {"home/"}}, []bool{true, true}},
+		{map[string][]string{"SourceIp": {"10.0.0.1"}, "prefix": {"home/"}}, []bool{false, true}},
+		{map[string][]string{"SourceIp": {"10.0.0.1"}}, []bool{false, false}},
+	}
+
+	for i, testCase := range testCases {
+		results := functions.Explain(testCase.values)
+		if len(results) != len(testCase.expectedMatched) {
+			t.Fatalf("case %v: expected: %v results, got: %v", i+1, len(testCase.expectedMatched), len(results))
+		}
+
+		allMatched := true
+		for j, result := range results {
+			if result.Matched != testCase.expectedMatched[j] {
+				t.Fatalf("case %v: function %v: expected: %v, got: %v", i+1, j+1, testCase.expectedMatched[j], result.Matched)
+			}
+			allMatched = allMatched && result.Matched
+		}
+
+		if allMatched != functions.Evaluate(testCase.values) {
+			t.Fatalf("case %v: explain does not agree with evaluate", i+1)
+		}
+	}
+
+	results := functions.Explain(map[string][]string{"SourceIp": {"10.0.0.1"}})
+	expected := Result{
+		Description: Description{
+			Name:   "IpAddress",
+			Key:    "aws:SourceIp",
+			Values: []string{"192.168.1.0/24"},
+		},
+		RequestValues: []string{"10.0.0.1"},
+	}
+	if !reflect.DeepEqual(results[0], expected) {
+		t.Fatalf("expected: %+v, got: %+v", expected, results[0])
+	}
+
+	if desc := functions.Describe()[1]; !reflect.DeepEqual(desc.Values, []string{"", "home/"}) {
+		t.Fatalf("expected sorted values, got: %v", desc.Values)
+	}
+}
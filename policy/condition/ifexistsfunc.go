@@ -0,0 +1,69 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ifExistsFunc - IfExists modifier of any condition function. It evaluates
+// to true if the key is not present in given values, otherwise it evaluates
+// the wrapped function.
+// For example,
+//   - StringEqualsIfExists {"aws:username": "alice"} matches requests of
+//     user "alice" and requests without a username.
+type ifExistsFunc struct {
+	Function
+}
+
+func (f ifExistsFunc) evaluate(values map[string][]string) bool {
+	if len(getValuesByKey(values, f.key())) == 0 {
+		return true
+	}
+	return f.Function.evaluate(values)
+}
+
+func (f ifExistsFunc) name() name {
+	n := f.Function.name()
+	n.ifExists = true
+	return n
+}
+
+func (f ifExistsFunc) String() string {
+	// Replace the leading name of the wrapped function.
+	return f.name().String() + strings.TrimPrefix(f.Function.String(), f.Function.name().String())
+}
+
+func (f ifExistsFunc) clone() Function {
+	return &ifExistsFunc{f.Function.clone()}
+}
+
+// NewIfExistsFunc - returns the IfExists variant of given function, for
+// example StringEqualsIfExists for a StringEquals function. Null functions
+// do not support IfExists.
+func NewIfExistsFunc(f Function) (Function, error) {
+	n := f.name()
+	if n.ifExists {
+		return nil, fmt.Errorf("%v is already an IfExists condition", n)
+	}
+	if n.name == null {
+		return nil, fmt.Errorf("%v condition does not support %v", null, ifExistsSuffix)
+	}
+	return &ifExistsFunc{f}, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIfExistsFuncEvaluate(t *testing.T) {
+	testCases := []struct {
+		data           string
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{`{"StringEqualsIfExists": {"aws:username": "alice"}}`, map[string][]string{"username": {"alice"}}, true},
+		{`{"StringEqualsIfExists": {"aws:username": "alice"}}`, map[string][]string{"username": {"bob"}}, false},
+		{`{"StringEqualsIfExists": {"aws:username": "alice"}}`, map[string][]string{}, true},
+		{`{"StringNotLikeIfExists": {"aws:username": "a*"}}`, map[string][]string{"username": {"alice"}}, false},
+		{`{"StringNotLikeIfExists": {"aws:username": "a*"}}`, map[string][]string{}, true},
+		{`{"ForAnyValue:StringEqualsIfExists": {"aws:groups": ["dev", "ops"]}}`, map[string][]string{"groups": {"dev"}}, true},
+		{`{"ForAnyValue:StringEqualsIfExists": {"aws:groups": ["dev", "ops"]}}`, map[string][]string{"groups": {"sales"}}, false},
+		{`{"ForAnyValue:StringEqualsIfExists": {"aws:groups": ["dev", "ops"]}}`, map[string][]string{}, true},
+		{`{"IpAddressIfExists": {"aws:SourceIp": "192.168.1.0/24"}}`, map[string][]string{"SourceIp": {"192.168.1.10"}}, true},
+		{`{"IpAddressIfExists": {"aws:SourceIp": "192.168.1.0/24"}}`, map[string][]string{"SourceIp": {"10.1.1.10"}}, false},
+		{`{"IpAddressIfExists": {"aws:SourceIp": "192.168.1.0/24"}}`, map[string][]string{}, true},
+		{`{"DateLessThanIfExists": {"aws:CurrentTime": "2013-06-30T00:00:00Z"}}`, map[string][]string{"CurrentTime": {"2013-06-29T00:00:00Z"}}, true},
+		{`{"DateLessThanIfExists": {"aws:CurrentTime": "2013-06-30T00:00:00Z"}}`, map[string][]string{"CurrentTime": {"2013-07-01T00:00:00Z"}}, false},
+		{`{"DateLessThanIfExists": {"aws:CurrentTime": "2013-06-30T00:00:00Z"}}`, map[string][]string{}, true},
+		{`{"NumericLessThanIfExists": {"s3:max-keys": "10"}}`, map[string][]string{"max-keys": {"100"}}, false},
+		{`{"NumericLessThanIfExists": {"s3:max-keys": "10"}}`, map[string][]string{}, true},
+		{`{"BoolIfExists": {"aws:SecureTransport": "true"}}`, map[string][]string{"SecureTransport": {"false"}}, false},
+		{`{"BoolIfExists": {"aws:SecureTransport": "true"}}`, map[string][]string{}, true},
+		{`{"ArnLikeIfExists": {"aws:SourceArn": "arn:aws:s3:::*"}}`, map[string][]string{}, true},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		if err := json.Unmarshal([]byte(testCase.data), &functions); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+
+		if result := functions.Evaluate(testCase.values); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestIfExistsFuncJSON(t *testing.T) {
+	testCases := []struct {
+		data      string
+		expectErr bool
+	}{
+		{`{"StringEqualsIfExists":{"aws:username":["alice"]}}`, false},
+		{`{"ForAllValues:StringLikeIfExists":{"aws:groups":["dev*"]}}`, false},
+		{`{"NumericGreaterThanIfExists":{"s3:max-keys":[10]}}`, false},
+		{`{"NotIpAddressIfExists":{"aws:SourceIp":["10.0.0.0/8"]}}`, false},
+		{`{"NullIfExists":{"aws:username":[true]}}`, true},
+		{`{"StringEqualsIfExistsIfExists":{"aws:username":["alice"]}}`, true},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		err := json.Unmarshal([]byte(testCase.data), &functions)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+		if testCase.expectErr {
+			continue
+		}
+
+		data, err := json.Marshal(functions)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if string(data) != testCase.data {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.data, string(data))
+		}
+		if clone := functions.Clone(); !clone.Equals(functions) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, functions, clone)
+		}
+	}
+}
+
+func TestNewIfExistsFunc(t *testing.T) {
+	f, err := NewStringEqualsFunc("", AWSUsername.ToKey(), "alice")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	ifExists, err := NewIfExistsFunc(f)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	if ifExists.name().String() != "StringEqualsIfExists" {
+		t.Fatalf("unexpected name %v", ifExists.name())
+	}
+	if ifExists.String() == f.String() {
+		t.Fatalf("expected IfExists function to differ from %v", f)
+	}
+
+	if _, err = NewIfExistsFunc(ifExists); err == nil {
+		t.Fatalf("error expected")
+	}
+
+	null, err := NewNullFunc(AWSUsername.ToKey(), true)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	if _, err = NewIfExistsFunc(null); err == nil {
+		t.Fatalf("error expected")
+	}
+}
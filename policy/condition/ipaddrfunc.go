@@ -20,6 +20,7 @@ package condition
 import (
 	"fmt"
 	"net"
+	"slices"
 	"sort"
 	"strings"
 )
@@ -33,7 +34,10 @@ type ipaddrFunc struct {
 	n      name
 	k      Key
 	values []*net.IPNet
-	negate bool
+	// variables hold policy variables whose values are IP addresses or
+	// networks, such as "${jwt:ip}", in addition to values.
+	variables []string
+	negate    bool
 }
 
 func (f ipaddrFunc) eval(values map[string][]string) bool {
@@ -59,9 +63,38 @@ func (f ipaddrFunc) eval(values map[string][]string) bool {
 	return false
 }
 
+// match - returns whether the given IP address satisfies the condition.
+func (f ipaddrFunc) match(s string) bool {
+	IP := net.ParseIP(s)
+	if IP == nil {
+		return false
+	}
+	for _, IPNet := range f.values {
+		if IPNet.Contains(IP) {
+			return !f.negate
+		}
+	}
+	return f.negate
+}
+
 // evaluate() - evaluates to check whether IP address in values map for AWSSourceIP
-// falls in one of network or not.
+// falls in one of network or not. If the function has a qualifier, every
+// IP address is checked on its own.
 func (f ipaddrFunc) evaluate(values map[string][]string) bool {
+	if len(f.variables) > 0 {
+		IPNets := slices.Clone(f.values)
+		for _, variable := range f.variables {
+			// Variables without a valid value are ignored.
+			if IPNet, err := parseIPNet(f.n.name, Substitute(variable, values)); err == nil {
+				IPNets = append(IPNets, IPNet)
+			}
+		}
+		f.values, f.variables = IPNets, nil
+	}
+
+	if f.n.qualifier != "" {
+		return matchValues(f.n.qualifier, getValuesByKey(values, f.k), f.match)
+	}
 	result := f.eval(values)
 	if f.negate {
 		return !result
@@ -85,6 +118,7 @@ func (f ipaddrFunc) String() string {
 	for _, value := range f.values {
 		valueStrings = append(valueStrings, value.String())
 	}
+	valueStrings = append(valueStrings, f.variables...)
 	sort.Strings(valueStrings)
 
 	return fmt.Sprintf("%v:%v:%v", f.n, f.k, valueStrings)
@@ -100,6 +134,9 @@ func (f ipaddrFunc) toMap() map[Key]ValueSet {
 	for _, value := range f.values {
 		values.Add(NewStringValue(value.String()))
 	}
+	for _, variable := range f.variables {
+		values.Add(NewStringValue(variable))
+	}
 
 	return map[Key]ValueSet{
 		f.k: values,
@@ -113,78 +150,96 @@ func (f ipaddrFunc) clone() Function {
 		values = append(values, IPNet)
 	}
 	return &ipaddrFunc{
-		n:      f.n,
-		k:      f.k,
-		values: values,
-		negate: f.negate,
+		n:         f.n,
+		k:         f.k,
+		values:    values,
+		variables: slices.Clone(f.variables),
+		negate:    f.negate,
+	}
+}
+
+func parseIPNet(n, s string) (*net.IPNet, error) {
+	// If you specify an IP address without the associated routing prefix, IAM uses the default prefix value of /32.
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements_condition_operators.html#Conditions_IPAddress
+	if strings.IndexByte(s, '/') == -1 {
+		s += "/32"
+	}
+
+	_, IPNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("value %v must be CIDR string for %v condition", s, n)
 	}
+	return IPNet, nil
 }
 
-func valuesToIPNets(n string, values ValueSet) ([]*net.IPNet, error) {
+// valuesToIPNets - returns the networks of given values, and the values
+// which are policy variables.
+func valuesToIPNets(n string, values ValueSet) ([]*net.IPNet, []string, error) {
 	IPNets := []*net.IPNet{}
+	var variables []string
 	for v := range values {
 		s, err := v.GetString()
 		if err != nil {
-			return nil, fmt.Errorf("value %v must be string representation of CIDR for %v condition", v, n)
+			return nil, nil, fmt.Errorf("value %v must be string representation of CIDR for %v condition", v, n)
 		}
 
-		// If you specify an IP address without the associated routing prefix, IAM uses the default prefix value of /32.
-		// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements_condition_operators.html#Conditions_IPAddress
-		if strings.IndexByte(s, '/') == -1 {
-			s += "/32"
+		if variable, ok := variableValue(NewValueSet(v)); ok {
+			variables = append(variables, variable)
+			continue
 		}
 
-		var IPNet *net.IPNet
-		_, IPNet, err = net.ParseCIDR(s)
+		IPNet, err := parseIPNet(n, s)
 		if err != nil {
-			return nil, fmt.Errorf("value %v must be CIDR string for %v condition", s, n)
+			return nil, nil, err
 		}
 
 		IPNets = append(IPNets, IPNet)
 	}
+	sort.Strings(variables)
 
-	return IPNets, nil
+	return IPNets, variables, nil
 }
 
-func newIPAddrFunc(n string, key Key, values []*net.IPNet, negate bool) (Function, error) {
+func newIPAddrFunc(n name, key Key, values []*net.IPNet, variables []string, negate bool) (Function, error) {
 	if !key.Is(AWSSourceIP) {
 		return nil, fmt.Errorf("only %v key is allowed for %v condition", AWSSourceIP, n)
 	}
 
 	return &ipaddrFunc{
-		n:      name{name: n},
-		k:      key,
-		values: values,
-		negate: negate,
+		n:         n,
+		k:         key,
+		values:    values,
+		variables: variables,
+		negate:    negate,
 	}, nil
 }
 
 // newIPAddressFunc - returns new IP address function.
-func newIPAddressFunc(key Key, values ValueSet, _ string) (Function, error) {
-	IPNets, err := valuesToIPNets(ipAddress, values)
+func newIPAddressFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	IPNets, variables, err := valuesToIPNets(ipAddress, values)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewIPAddressFunc(key, IPNets...)
+	return newIPAddrFunc(name{qualifier: qualifier, name: ipAddress}, key, IPNets, variables, false)
 }
 
 // NewIPAddressFunc - returns new IP address function.
 func NewIPAddressFunc(key Key, IPNets ...*net.IPNet) (Function, error) {
-	return newIPAddrFunc(ipAddress, key, IPNets, false)
+	return newIPAddrFunc(name{name: ipAddress}, key, IPNets, nil, false)
 }
 
 // newNotIPAddressFunc - returns new Not IP address function.
-func newNotIPAddressFunc(key Key, values ValueSet, _ string) (Function, error) {
-	IPNets, err := valuesToIPNets(notIPAddress, values)
+func newNotIPAddressFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	IPNets, variables, err := valuesToIPNets(notIPAddress, values)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewNotIPAddressFunc(key, IPNets...)
+	return newIPAddrFunc(name{qualifier: qualifier, name: notIPAddress}, key, IPNets, variables, true)
 }
 
 // NewNotIPAddressFunc - returns new Not IP address function.
 func NewNotIPAddressFunc(key Key, IPNets ...*net.IPNet) (Function, error) {
-	return newIPAddrFunc(notIPAddress, key, IPNets, true)
+	return newIPAddrFunc(name{name: notIPAddress}, key, IPNets, nil, true)
 }
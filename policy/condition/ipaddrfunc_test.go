@@ -303,3 +303,41 @@ func TestNewNotIPAddressFunc(t *testing.T) {
 		}
 	}
 }
+
+func TestIPAddrFuncQualifier(t *testing.T) {
+	testCases := []struct {
+		data           string
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{`{"ForAnyValue:IpAddress":{"aws:SourceIp":["10.0.0.0/8"]}}`, map[string][]string{"SourceIp": {"192.168.1.1", "10.1.1.1"}}, true},
+		{`{"ForAnyValue:IpAddress":{"aws:SourceIp":["10.0.0.0/8"]}}`, map[string][]string{"SourceIp": {"192.168.1.1"}}, false},
+		{`{"ForAnyValue:IpAddress":{"aws:SourceIp":["10.0.0.0/8"]}}`, map[string][]string{}, false},
+		{`{"ForAllValues:IpAddress":{"aws:SourceIp":["10.0.0.0/8"]}}`, map[string][]string{"SourceIp": {"10.2.2.2", "10.1.1.1"}}, true},
+		{`{"ForAllValues:IpAddress":{"aws:SourceIp":["10.0.0.0/8"]}}`, map[string][]string{"SourceIp": {"192.168.1.1", "10.1.1.1"}}, false},
+		{`{"ForAllValues:IpAddress":{"aws:SourceIp":["10.0.0.0/8"]}}`, map[string][]string{}, true},
+		{`{"ForAllValues:NotIpAddress":{"aws:SourceIp":["10.0.0.0/8"]}}`, map[string][]string{"SourceIp": {"192.168.1.1", "10.1.1.1"}}, false},
+		{`{"ForAnyValue:NotIpAddress":{"aws:SourceIp":["10.0.0.0/8"]}}`, map[string][]string{"SourceIp": {"192.168.1.1", "10.1.1.1"}}, true},
+		{`{"ForAnyValue:NotIpAddress":{"aws:SourceIp":["10.0.0.0/8"]}}`, map[string][]string{"SourceIp": {"invalid"}}, false},
+		{`{"ForAnyValue:NotIpAddress":{"aws:SourceIp":["10.0.0.0/8"]}}`, map[string][]string{}, false},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		if err := json.Unmarshal([]byte(testCase.data), &functions); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+
+		if result := functions.Evaluate(testCase.values); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+
+		data, err := json.Marshal(functions)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if string(data) != testCase.data {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.data, string(data))
+		}
+	}
+}
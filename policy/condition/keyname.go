@@ -161,6 +161,22 @@ const (
 	// AWSGroups - groups for any authenticating Access Key.
 	AWSGroups KeyName = "aws:groups"
 
+	// AWSSourceArn - ARN of the resource making a service-to-service request.
+	// It is not derived from the request, the caller must set "SourceArn" in
+	// the condition values, otherwise conditions on this key never match.
+	AWSSourceArn KeyName = "aws:SourceArn"
+
+	// AWSPrincipalArn - ARN of the principal making the request, such as the
+	// ARN of an assumed role. It is not derived from the request, the caller
+	// must set "PrincipalArn" in the condition values, otherwise conditions
+	// on this key never match.
+	AWSPrincipalArn KeyName = "aws:PrincipalArn"
+
+	// AWSPrincipalTag - tags attached to the principal making the request,
+	// used with a tag key such as "${aws:PrincipalTag/team}". It is a policy
+	// variable only, not a condition key.
+	AWSPrincipalTag KeyName = "aws:PrincipalTag"
+
 	// S3SignatureVersion - identifies the version of AWS Signature that you want to support for authenticated requests.
 	S3SignatureVersion KeyName = "s3:signatureversion"
 
@@ -295,6 +311,8 @@ var AllSupportedKeys = []KeyName{
 	AWSUserID,
 	AWSUsername,
 	AWSGroups,
+	AWSSourceArn,
+	AWSPrincipalArn,
 	LDAPUser,
 	LDAPUsername,
 	LDAPGroups,
@@ -345,6 +363,8 @@ var CommonKeys = append([]KeyName{
 	AWSUserID,
 	AWSUsername,
 	AWSGroups,
+	AWSSourceArn,
+	AWSPrincipalArn,
 	LDAPUser,
 	LDAPUsername,
 	LDAPGroups,
@@ -372,6 +392,8 @@ var AllSupportedAdminKeys = append([]KeyName{
 	AWSUserID,
 	AWSUsername,
 	AWSGroups,
+	AWSSourceArn,
+	AWSPrincipalArn,
 	LDAPUser,
 	LDAPUsername,
 	LDAPGroups,
@@ -390,5 +412,6 @@ var AllSupportedSTSKeys = []KeyName{
 	LDAPGroups,
 	LDAPUsername,
 	AWSUsername,
+	AWSPrincipalArn,
 	// Add new supported condition keys.
 }
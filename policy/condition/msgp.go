@@ -0,0 +1,222 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+// decimalExtension - MessagePack extension type of float values. They are
+// stored as their decimal text so that no precision is lost.
+const decimalExtension int8 = 1
+
+// appendValue - appends the MessagePack encoding of given value to b.
+func appendValue(b []byte, v Value) ([]byte, error) {
+	switch v.t {
+	case reflect.String:
+		return msgp.AppendString(b, v.s), nil
+	case reflect.Int:
+		return msgp.AppendInt64(b, v.i), nil
+	case reflect.Float64:
+		return msgp.AppendExtension(b, &msgp.RawExtension{Type: decimalExtension, Data: []byte(v.s)})
+	case reflect.Bool:
+		return msgp.AppendBool(b, v.b), nil
+	}
+
+	return b, fmt.Errorf("unknown value kind %v", v.t)
+}
+
+// readValue - reads a value encoded by appendValue() from b.
+func readValue(b []byte) (v Value, o []byte, err error) {
+	switch msgp.NextType(b) {
+	case msgp.StrType:
+		var s string
+		s, o, err = msgp.ReadStringBytes(b)
+		v.StoreString(s)
+	case msgp.IntType, msgp.UintType:
+		var i int64
+		i, o, err = msgp.ReadInt64Bytes(b)
+		v.StoreInt64(i)
+	case msgp.ExtensionType:
+		ext := msgp.RawExtension{Type: decimalExtension}
+		o, err = msgp.ReadExtensionBytes(b, &ext)
+		if err != nil {
+			break
+		}
+		if _, ok := parseDecimal(string(ext.Data)); !ok {
+			return v, b, fmt.Errorf("invalid decimal value '%s'", ext.Data)
+		}
+		v.storeDecimal(string(ext.Data))
+	case msgp.BoolType:
+		var bv bool
+		bv, o, err = msgp.ReadBoolBytes(b)
+		v.StoreBool(bv)
+	default:
+		return v, b, fmt.Errorf("unknown value type %v", msgp.NextType(b))
+	}
+	return v, o, err
+}
+
+// valueMsgsize - returns the maximum serialized size of given value in bytes.
+func valueMsgsize(v Value) int {
+	switch v.t {
+	case reflect.Int:
+		return msgp.Int64Size
+	case reflect.Float64:
+		return msgp.ExtensionPrefixSize + len(v.s)
+	case reflect.Bool:
+		return msgp.BoolSize
+	}
+	return msgp.StringPrefixSize + len(v.s)
+}
+
+// MarshalMsg - appends the MessagePack encoding of Functions to b. Each
+// function is encoded as an array of its name, key and sorted values.
+func (functions Functions) MarshalMsg(b []byte) (o []byte, err error) {
+	if functions == nil {
+		return msgp.AppendNil(b), nil
+	}
+
+	o = msgp.AppendArrayHeader(b, uint32(len(functions)))
+	for i, f := range functions {
+		o = msgp.AppendArrayHeader(o, 3)
+		o = msgp.AppendString(o, f.name().String())
+		o = msgp.AppendString(o, f.key().String())
+		var values []Value
+		for _, set := range f.toMap() {
+			values = append(values, set.ToSlice()...)
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i].String() < values[j].String() })
+		o = msgp.AppendArrayHeader(o, uint32(len(values)))
+		for _, value := range values {
+			if o, err = appendValue(o, value); err != nil {
+				return b, msgp.WrapError(err, i)
+			}
+		}
+	}
+	return o, nil
+}
+
+// UnmarshalMsg - decodes MessagePack data encoded by MarshalMsg() to
+// Functions and returns the remaining bytes.
+func (functions *Functions) UnmarshalMsg(b []byte) (o []byte, err error) {
+	if msgp.IsNil(b) {
+		*functions = nil
+		return msgp.ReadNilBytes(b)
+	}
+
+	sz, o, err := msgp.ReadArrayHeaderBytes(b)
+	if err != nil {
+		return b, err
+	}
+
+	funcs := make(Functions, 0, sz)
+	for i := uint32(0); i < sz; i++ {
+		f, rem, err := readFunction(o)
+		if err != nil {
+			return b, msgp.WrapError(err, i)
+		}
+		funcs = append(funcs, f)
+		o = rem
+	}
+
+	*functions = funcs
+	return o, nil
+}
+
+func readFunction(b []byte) (Function, []byte, error) {
+	sz, o, err := msgp.ReadArrayHeaderBytes(b)
+	if err != nil {
+		return nil, b, err
+	}
+	if sz != 3 {
+		return nil, b, msgp.ArrayError{Wanted: 3, Got: sz}
+	}
+
+	var nameString, keyString string
+	if nameString, o, err = msgp.ReadStringBytes(o); err != nil {
+		return nil, b, err
+	}
+	if keyString, o, err = msgp.ReadStringBytes(o); err != nil {
+		return nil, b, err
+	}
+	if sz, o, err = msgp.ReadArrayHeaderBytes(o); err != nil {
+		return nil, b, err
+	}
+	values := make(ValueSet, sz)
+	for i := uint32(0); i < sz; i++ {
+		var value Value
+		if value, o, err = readValue(o); err != nil {
+			return nil, b, err
+		}
+		values.Add(value)
+	}
+
+	n, err := parseName(nameString)
+	if err != nil {
+		return nil, b, err
+	}
+	key, err := parseKey(keyString)
+	if err != nil {
+		return nil, b, err
+	}
+	f, err := newFunction(n, key, values)
+	if err != nil {
+		return nil, b, err
+	}
+	return f, o, nil
+}
+
+// EncodeMsg - writes Functions as MessagePack to w.
+func (functions Functions) EncodeMsg(w *msgp.Writer) error {
+	b, err := functions.MarshalMsg(nil)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// DecodeMsg - reads Functions as MessagePack from r.
+func (functions *Functions) DecodeMsg(r *msgp.Reader) error {
+	var raw msgp.Raw
+	if err := raw.DecodeMsg(r); err != nil {
+		return err
+	}
+	_, err := functions.UnmarshalMsg(raw)
+	return err
+}
+
+// Msgsize - returns the maximum serialized size of Functions in bytes.
+func (functions Functions) Msgsize() int {
+	s := msgp.ArrayHeaderSize
+	for _, f := range functions {
+		s += msgp.ArrayHeaderSize + msgp.StringPrefixSize + len(f.name().String()) +
+			msgp.StringPrefixSize + len(f.key().String()) + msgp.ArrayHeaderSize
+		for _, set := range f.toMap() {
+			for value := range set {
+				s += valueMsgsize(value)
+			}
+		}
+	}
+	return s
+}
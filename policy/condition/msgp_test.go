@@ -0,0 +1,113 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+func TestFunctionsMsgp(t *testing.T) {
+	testCases := []struct {
+		data string
+	}{
+		{`{"StringEquals": {"s3:prefix": ["foo", "bar"]}}`},
+		{`{"ForAnyValue:StringLikeIfExists": {"s3:prefix": ["env*"]}, "Bool": {"aws:SecureTransport": [true]}}`},
+		{`{"NumericLessThan": {"s3:max-keys": [9007199254740993]}, "NumericGreaterThan": {"s3:object-lock-remaining-retention-days": [1.50]}}`},
+		{`{"IpAddress": {"aws:SourceIp": ["192.168.1.0/24"]}, "Null": {"s3:x-amz-server-side-encryption": [false]}}`},
+		{`{"DateGreaterThan": {"aws:CurrentTime": ["2013-06-30T00:00:00Z"]}}`},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		if err := json.Unmarshal([]byte(testCase.data), &functions); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+
+		data, err := functions.MarshalMsg(nil)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if len(data) > functions.Msgsize() {
+			t.Fatalf("case %v: Msgsize() %v is less than encoded size %v", i+1, functions.Msgsize(), len(data))
+		}
+
+		var result Functions
+		left, err := result.UnmarshalMsg(data)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if len(left) > 0 {
+			t.Fatalf("case %v: %v bytes left over", i+1, len(left))
+		}
+		if !result.Equals(functions) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, functions, result)
+		}
+
+		var buf bytes.Buffer
+		if err = msgp.Encode(&buf, functions); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		result = nil
+		if err = msgp.Decode(&buf, &result); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if !result.Equals(functions) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, functions, result)
+		}
+	}
+}
+
+func TestFunctionsUnmarshalMsgError(t *testing.T) {
+	encode := func(name, key string, values ...any) []byte {
+		o := msgp.AppendArrayHeader(nil, 1)
+		o = msgp.AppendArrayHeader(o, 3)
+		o = msgp.AppendString(o, name)
+		o = msgp.AppendString(o, key)
+		o = msgp.AppendArrayHeader(o, uint32(len(values)))
+		for _, value := range values {
+			o, _ = msgp.AppendIntf(o, value)
+		}
+		return o
+	}
+
+	testCases := []struct {
+		data      []byte
+		expectErr bool
+	}{
+		{encode("StringEquals", "s3:prefix", "foo"), false},
+		{encode("StringEqual", "s3:prefix", "foo"), true},
+		{encode("StringEquals", "s3:unknown", "foo"), true},
+		{encode("NumericEquals", "s3:max-keys", 1.5), true},
+		{encode("StringEquals", "s3:prefix", []string{"foo"}), true},
+		{encode("StringEquals", "s3:prefix", "foo")[:5], true},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		_, err := functions.UnmarshalMsg(testCase.data)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v\n", i+1, testCase.expectErr, expectErr)
+		}
+	}
+}
@@ -25,30 +25,37 @@ import (
 
 const (
 	// names
-	stringEquals               = "StringEquals"
-	stringNotEquals            = "StringNotEquals"
-	stringEqualsIgnoreCase     = "StringEqualsIgnoreCase"
-	stringNotEqualsIgnoreCase  = "StringNotEqualsIgnoreCase"
-	stringLike                 = "StringLike"
-	stringNotLike              = "StringNotLike"
-	binaryEquals               = "BinaryEquals"
-	ipAddress                  = "IpAddress"
-	notIPAddress               = "NotIpAddress"
-	null                       = "Null"
-	boolean                    = "Bool"
-	numericEquals              = "NumericEquals"
-	numericNotEquals           = "NumericNotEquals"
-	numericLessThan            = "NumericLessThan"
-	numericLessThanEquals      = "NumericLessThanEquals"
-	numericGreaterThan         = "NumericGreaterThan"
-	numericGreaterThanIfExists = "NumericGreaterThanIfExists"
-	numericGreaterThanEquals   = "NumericGreaterThanEquals"
-	dateEquals                 = "DateEquals"
-	dateNotEquals              = "DateNotEquals"
-	dateLessThan               = "DateLessThan"
-	dateLessThanEquals         = "DateLessThanEquals"
-	dateGreaterThan            = "DateGreaterThan"
-	dateGreaterThanEquals      = "DateGreaterThanEquals"
+	stringEquals              = "StringEquals"
+	stringNotEquals           = "StringNotEquals"
+	stringEqualsIgnoreCase    = "StringEqualsIgnoreCase"
+	stringNotEqualsIgnoreCase = "StringNotEqualsIgnoreCase"
+	stringLike                = "StringLike"
+	stringNotLike             = "StringNotLike"
+	binaryEquals              = "BinaryEquals"
+	ipAddress                 = "IpAddress"
+	notIPAddress              = "NotIpAddress"
+	null                      = "Null"
+	boolean                   = "Bool"
+	numericEquals             = "NumericEquals"
+	numericNotEquals          = "NumericNotEquals"
+	numericLessThan           = "NumericLessThan"
+	numericLessThanEquals     = "NumericLessThanEquals"
+	numericGreaterThan        = "NumericGreaterThan"
+	numericGreaterThanEquals  = "NumericGreaterThanEquals"
+	dateEquals                = "DateEquals"
+	dateNotEquals             = "DateNotEquals"
+	dateLessThan              = "DateLessThan"
+	dateLessThanEquals        = "DateLessThanEquals"
+	dateGreaterThan           = "DateGreaterThan"
+	dateGreaterThanEquals     = "DateGreaterThanEquals"
+	arnEquals                 = "ArnEquals"
+	arnNotEquals              = "ArnNotEquals"
+	arnLike                   = "ArnLike"
+	arnNotLike                = "ArnNotLike"
+
+	// ifExistsSuffix - modifier for any condition name except Null, which
+	// makes the condition true if the key is not present in the request.
+	ifExistsSuffix = "IfExists"
 
 	// qualifiers
 	// refer https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_multi-value-conditions.html#reference_policies_multi-key-or-value-conditions
@@ -57,30 +64,33 @@ const (
 )
 
 var names = map[string]struct{}{
-	stringEquals:               {},
-	stringNotEquals:            {},
-	stringEqualsIgnoreCase:     {},
-	stringNotEqualsIgnoreCase:  {},
-	binaryEquals:               {},
-	stringLike:                 {},
-	stringNotLike:              {},
-	ipAddress:                  {},
-	notIPAddress:               {},
-	null:                       {},
-	boolean:                    {},
-	numericEquals:              {},
-	numericNotEquals:           {},
-	numericLessThan:            {},
-	numericLessThanEquals:      {},
-	numericGreaterThan:         {},
-	numericGreaterThanIfExists: {},
-	numericGreaterThanEquals:   {},
-	dateEquals:                 {},
-	dateNotEquals:              {},
-	dateLessThan:               {},
-	dateLessThanEquals:         {},
-	dateGreaterThan:            {},
-	dateGreaterThanEquals:      {},
+	stringEquals:              {},
+	stringNotEquals:           {},
+	stringEqualsIgnoreCase:    {},
+	stringNotEqualsIgnoreCase: {},
+	binaryEquals:              {},
+	stringLike:                {},
+	stringNotLike:             {},
+	ipAddress:                 {},
+	notIPAddress:              {},
+	null:                      {},
+	boolean:                   {},
+	numericEquals:             {},
+	numericNotEquals:          {},
+	numericLessThan:           {},
+	numericLessThanEquals:     {},
+	numericGreaterThan:        {},
+	numericGreaterThanEquals:  {},
+	dateEquals:                {},
+	dateNotEquals:             {},
+	dateLessThan:              {},
+	dateLessThanEquals:        {},
+	dateGreaterThan:           {},
+	dateGreaterThanEquals:     {},
+	arnEquals:                 {},
+	arnNotEquals:              {},
+	arnLike:                   {},
+	arnNotLike:                {},
 }
 
 var qualifiers = map[string]struct{}{
@@ -91,13 +101,18 @@ var qualifiers = map[string]struct{}{
 type name struct {
 	qualifier string
 	name      string
+	ifExists  bool
 }
 
 func (n name) String() string {
+	s := n.name
+	if n.ifExists {
+		s += ifExistsSuffix
+	}
 	if n.qualifier != "" {
-		return n.qualifier + ":" + n.name
+		return n.qualifier + ":" + s
 	}
-	return n.name
+	return s
 }
 
 // IsValid - checks if name is valid or not.
@@ -108,7 +123,14 @@ func (n name) IsValid() bool {
 		}
 	}
 
-	_, found := names[n.name]
+	if n.ifExists && n.name == null {
+		return false
+	}
+
+	if _, found := names[n.name]; found {
+		return true
+	}
+	_, found := lookupOperator(n.name)
 	return found
 }
 
@@ -148,6 +170,7 @@ func parseName(s string) (name, error) {
 	default:
 		return n, fmt.Errorf("invalid condition name '%v'", s)
 	}
+	n.name, n.ifExists = strings.CutSuffix(n.name, ifExistsSuffix)
 
 	if n.IsValid() {
 		return n, nil
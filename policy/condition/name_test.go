@@ -38,6 +38,9 @@ func TestNameIsValid(t *testing.T) {
 		{name{name: "foo"}, false},
 		{name{qualifier: forAllValues, name: stringEquals}, true},
 		{name{qualifier: forAnyValue, name: stringNotEquals}, true},
+		{name{name: dateLessThan, ifExists: true}, true},
+		{name{qualifier: forAnyValue, name: stringLike, ifExists: true}, true},
+		{name{name: null, ifExists: true}, false},
 	}
 
 	for i, testCase := range testCases {
@@ -65,6 +68,8 @@ func TestNameMarshalJSON(t *testing.T) {
 		{name{name: "foo"}, nil, true},
 		{name{qualifier: forAllValues, name: stringEquals}, []byte(`"ForAllValues:StringEquals"`), false},
 		{name{qualifier: forAnyValue, name: stringNotEquals}, []byte(`"ForAnyValue:StringNotEquals"`), false},
+		{name{name: ipAddress, ifExists: true}, []byte(`"IpAddressIfExists"`), false},
+		{name{qualifier: forAllValues, name: stringEquals, ifExists: true}, []byte(`"ForAllValues:StringEqualsIfExists"`), false},
 	}
 
 	for i, testCase := range testCases {
@@ -93,6 +98,10 @@ func TestNameUnmarshalJSON(t *testing.T) {
 		{[]byte(`"foo"`), name{name: ""}, true},
 		{[]byte(`"ForAllValues:StringEquals"`), name{qualifier: forAllValues, name: stringEquals}, false},
 		{[]byte(`"ForAnyValue:StringNotEquals"`), name{qualifier: forAnyValue, name: stringNotEquals}, false},
+		{[]byte(`"NumericGreaterThanIfExists"`), name{name: numericGreaterThan, ifExists: true}, false},
+		{[]byte(`"ForAnyValue:StringLikeIfExists"`), name{qualifier: forAnyValue, name: stringLike, ifExists: true}, false},
+		{[]byte(`"NullIfExists"`), name{}, true},
+		{[]byte(`"IfExists"`), name{}, true},
 	}
 
 	for i, testCase := range testCases {
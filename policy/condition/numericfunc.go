@@ -19,42 +19,94 @@ package condition
 
 import (
 	"fmt"
+	"math/big"
 	"reflect"
+	"regexp"
 	"strconv"
 )
 
 type numericFunc struct {
-	n        name
-	k        Key
-	value    int
-	c        condition
-	ifExists bool
+	n     name
+	k     Key
+	value int64
+	// r holds the value of decimal conditions, value is unused if set.
+	r *big.Rat
+	c condition
+	// variable holds the policy variable whose value is compared, such as
+	// "${jwt:exp}", value and r are unused if set.
+	variable string
 }
 
-func (f numericFunc) evaluate(values map[string][]string) (ret bool) {
+// decimalRegexp - plain decimal numbers with an exponent of at most three
+// digits, as big.Rat also accepts fractions, base prefixes such as "0x" and
+// exponents which take long to compute.
+var decimalRegexp = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]{1,3})?$`)
+
+// parseDecimal - parses the decimal number without losing precision.
+func parseDecimal(s string) (*big.Rat, bool) {
+	if !decimalRegexp.MatchString(s) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
+
+// evaluate() - evaluates the condition for the first request value, or for
+// all of them if the function has a qualifier.
+func (f numericFunc) evaluate(values map[string][]string) bool {
+	if f.variable != "" {
+		v, r, err := valueToNumber(f.n.name, NewValueSet(NewStringValue(Substitute(f.variable, values))))
+		if err != nil {
+			// The variable has no value or it is not a number.
+			return false
+		}
+		f.value, f.r, f.variable = v, r, ""
+	}
+
 	rvalues := getValuesByKey(values, f.k)
+	if f.n.qualifier != "" {
+		return matchValues(f.n.qualifier, rvalues, f.match)
+	}
 	if len(rvalues) == 0 {
-		return f.ifExists
+		return false
 	}
+	return f.match(rvalues[0])
+}
 
-	rv, err := strconv.Atoi(rvalues[0])
-	if err != nil {
-		return false
+// match - returns whether the given request value satisfies the condition.
+func (f numericFunc) match(s string) bool {
+	var cmp int
+	if rv, err := strconv.ParseInt(s, 10, 64); err == nil && f.r == nil {
+		switch {
+		case rv < f.value:
+			cmp = -1
+		case rv > f.value:
+			cmp = 1
+		}
+	} else {
+		r, ok := parseDecimal(s)
+		if !ok {
+			return false
+		}
+		value := f.r
+		if value == nil {
+			value = new(big.Rat).SetInt64(f.value)
+		}
+		cmp = r.Cmp(value)
 	}
 
 	switch f.c {
 	case equals:
-		return rv == f.value
+		return cmp == 0
 	case notEquals:
-		return rv != f.value
+		return cmp != 0
 	case greaterThan:
-		return rv > f.value
+		return cmp > 0
 	case greaterThanEquals:
-		return rv >= f.value
+		return cmp >= 0
 	case lessThan:
-		return rv < f.value
+		return cmp < 0
 	case lessThanEquals:
-		return rv <= f.value
+		return cmp <= 0
 	}
 
 	// This never happens.
@@ -69,8 +121,33 @@ func (f numericFunc) name() name {
 	return f.n
 }
 
+func (f numericFunc) valueOf() Value {
+	if f.variable != "" {
+		return NewStringValue(f.variable)
+	}
+	if f.r != nil {
+		v := Value{}
+		v.storeDecimal(decimalString(f.r))
+		return v
+	}
+	return NewInt64Value(f.value)
+}
+
+// decimalString - returns the shortest exact decimal representation of r,
+// which is always possible for values parsed from decimals.
+func decimalString(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	prec, exact := r.FloatPrec()
+	if !exact {
+		return r.FloatString(16)
+	}
+	return r.FloatString(prec)
+}
+
 func (f numericFunc) String() string {
-	return fmt.Sprintf("%v:%v:%v:%v", f.n, f.ifExists, f.k, f.value)
+	return fmt.Sprintf("%v:%v:%v", f.n, f.k, f.valueOf())
 }
 
 func (f numericFunc) toMap() map[Key]ValueSet {
@@ -79,7 +156,7 @@ func (f numericFunc) toMap() map[Key]ValueSet {
 	}
 
 	values := NewValueSet()
-	values.Add(NewIntValue(f.value))
+	values.Add(f.valueOf())
 
 	return map[Key]ValueSet{
 		f.k: values,
@@ -91,119 +168,195 @@ func (f numericFunc) clone() Function {
 		n:        f.n,
 		k:        f.k,
 		value:    f.value,
+		r:        f.r,
 		c:        f.c,
-		ifExists: f.ifExists,
+		variable: f.variable,
 	}
 }
 
-func valueToInt(n string, values ValueSet) (v int, err error) {
+// valueToNumber - returns the integer or decimal value of a numeric
+// condition, the decimal is nil for integers.
+func valueToNumber(n string, values ValueSet) (v int64, r *big.Rat, err error) {
 	if len(values) != 1 {
-		return -1, fmt.Errorf("only one value is allowed for %s condition", n)
+		return -1, nil, fmt.Errorf("only one value is allowed for %s condition", n)
 	}
 
 	for vs := range values {
+		var s string
 		switch vs.GetType() {
 		case reflect.Int:
-			if v, err = vs.GetInt(); err != nil {
-				return -1, err
-			}
-		case reflect.String:
-			s, err := vs.GetString()
-			if err != nil {
-				return -1, err
-			}
-			if v, err = strconv.Atoi(s); err != nil {
-				return -1, fmt.Errorf("value %s must be a int for %s condition: %w", vs, n, err)
-			}
+			v, err = vs.GetInt64()
+			return v, nil, err
+		case reflect.Float64, reflect.String:
+			s = vs.String()
 		default:
-			return -1, fmt.Errorf("value %s must be a int for %s condition", vs, n)
+			return -1, nil, fmt.Errorf("value %s must be a number for %s condition", vs, n)
+		}
+
+		if v, err = strconv.ParseInt(s, 10, 64); err == nil {
+			return v, nil, nil
+		}
+		var ok bool
+		if r, ok = parseDecimal(s); !ok {
+			return -1, nil, fmt.Errorf("value %s must be a number for %s condition", vs, n)
+		}
+		if r.IsInt() && r.Num().IsInt64() {
+			return r.Num().Int64(), nil, nil
 		}
 	}
 
-	return v, nil
+	return -1, r, nil
 }
 
-func newNumericFunc(n string, ifExists bool, key Key, values ValueSet, cond condition) (Function, error) {
-	v, err := valueToInt(n, values)
+func newNumericFunc(n string, key Key, values ValueSet, qualifier string, cond condition) (Function, error) {
+	if variable, ok := variableValue(values); ok {
+		return &numericFunc{
+			n:        name{qualifier: qualifier, name: n},
+			k:        key,
+			c:        cond,
+			variable: variable,
+		}, nil
+	}
+
+	v, r, err := valueToNumber(n, values)
 	if err != nil {
 		return nil, err
 	}
 
 	return &numericFunc{
-		n:        name{name: n},
-		k:        key,
-		value:    v,
-		c:        cond,
-		ifExists: ifExists,
+		n:     name{qualifier: qualifier, name: n},
+		k:     key,
+		value: v,
+		r:     r,
+		c:     cond,
 	}, nil
 }
 
+func newNumericFloatFunc(n string, key Key, value float64, cond condition) (Function, error) {
+	return newNumericFunc(n, key, NewValueSet(NewFloatValue(value)), "", cond)
+}
+
 // newNumericEqualsFunc - returns new NumericEquals function.
-func newNumericEqualsFunc(key Key, values ValueSet, _ string) (Function, error) {
-	return newNumericFunc(numericEquals, false, key, values, equals)
+func newNumericEqualsFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newNumericFunc(numericEquals, key, values, qualifier, equals)
 }
 
 // NewNumericEqualsFunc - returns new NumericEquals function.
 func NewNumericEqualsFunc(key Key, value int) (Function, error) {
+	return &numericFunc{n: name{name: numericEquals}, k: key, value: int64(value), c: equals}, nil
+}
+
+// NewNumericEqualsInt64Func - returns new NumericEquals function for int64 value.
+func NewNumericEqualsInt64Func(key Key, value int64) (Function, error) {
 	return &numericFunc{n: name{name: numericEquals}, k: key, value: value, c: equals}, nil
 }
 
+// NewNumericEqualsFloatFunc - returns new NumericEquals function for float value.
+func NewNumericEqualsFloatFunc(key Key, value float64) (Function, error) {
+	return newNumericFloatFunc(numericEquals, key, value, equals)
+}
+
 // newNumericNotEqualsFunc - returns new NumericNotEquals function.
-func newNumericNotEqualsFunc(key Key, values ValueSet, _ string) (Function, error) {
-	return newNumericFunc(numericNotEquals, false, key, values, notEquals)
+func newNumericNotEqualsFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newNumericFunc(numericNotEquals, key, values, qualifier, notEquals)
 }
 
 // NewNumericNotEqualsFunc - returns new NumericNotEquals function.
 func NewNumericNotEqualsFunc(key Key, value int) (Function, error) {
+	return &numericFunc{n: name{name: numericNotEquals}, k: key, value: int64(value), c: notEquals}, nil
+}
+
+// NewNumericNotEqualsInt64Func - returns new NumericNotEquals function for int64 value.
+func NewNumericNotEqualsInt64Func(key Key, value int64) (Function, error) {
 	return &numericFunc{n: name{name: numericNotEquals}, k: key, value: value, c: notEquals}, nil
 }
 
+// NewNumericNotEqualsFloatFunc - returns new NumericNotEquals function for float value.
+func NewNumericNotEqualsFloatFunc(key Key, value float64) (Function, error) {
+	return newNumericFloatFunc(numericNotEquals, key, value, notEquals)
+}
+
 // newNumericGreaterThanFunc - returns new NumericGreaterThan function.
-func newNumericGreaterThanFunc(key Key, values ValueSet, _ string) (Function, error) {
-	return newNumericFunc(numericGreaterThan, false, key, values, greaterThan)
+func newNumericGreaterThanFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newNumericFunc(numericGreaterThan, key, values, qualifier, greaterThan)
 }
 
 // NewNumericGreaterThanFunc - returns new NumericGreaterThan function.
 func NewNumericGreaterThanFunc(key Key, value int) (Function, error) {
+	return &numericFunc{n: name{name: numericGreaterThan}, k: key, value: int64(value), c: greaterThan}, nil
+}
+
+// NewNumericGreaterThanInt64Func - returns new NumericGreaterThan function for int64 value.
+func NewNumericGreaterThanInt64Func(key Key, value int64) (Function, error) {
 	return &numericFunc{n: name{name: numericGreaterThan}, k: key, value: value, c: greaterThan}, nil
 }
 
-// newNumericGreaterThanIfExistsFunc - returns new NumericGreaterThanIfExists function.
-func newNumericGreaterThanIfExistsFunc(key Key, values ValueSet, _ string) (Function, error) {
-	return newNumericFunc(numericGreaterThanIfExists, true, key, values, greaterThan)
+// NewNumericGreaterThanFloatFunc - returns new NumericGreaterThan function for float value.
+func NewNumericGreaterThanFloatFunc(key Key, value float64) (Function, error) {
+	return newNumericFloatFunc(numericGreaterThan, key, value, greaterThan)
 }
 
 // NewNumericGreaterThanIfExistsFunc - returns new NumericGreaterThanIfExists function.
 func NewNumericGreaterThanIfExistsFunc(key Key, value int) (Function, error) {
-	return &numericFunc{n: name{name: numericGreaterThan}, ifExists: true, k: key, value: value, c: greaterThan}, nil
+	return NewIfExistsFunc(&numericFunc{n: name{name: numericGreaterThan}, k: key, value: int64(value), c: greaterThan})
 }
 
 // newNumericGreaterThanEqualsFunc - returns new NumericGreaterThanEquals function.
-func newNumericGreaterThanEqualsFunc(key Key, values ValueSet, _ string) (Function, error) {
-	return newNumericFunc(numericGreaterThanEquals, false, key, values, greaterThanEquals)
+func newNumericGreaterThanEqualsFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newNumericFunc(numericGreaterThanEquals, key, values, qualifier, greaterThanEquals)
 }
 
 // NewNumericGreaterThanEqualsFunc - returns new NumericGreaterThanEquals function.
 func NewNumericGreaterThanEqualsFunc(key Key, value int) (Function, error) {
+	return &numericFunc{n: name{name: numericGreaterThanEquals}, k: key, value: int64(value), c: greaterThanEquals}, nil
+}
+
+// NewNumericGreaterThanEqualsInt64Func - returns new NumericGreaterThanEquals function for int64 value.
+func NewNumericGreaterThanEqualsInt64Func(key Key, value int64) (Function, error) {
 	return &numericFunc{n: name{name: numericGreaterThanEquals}, k: key, value: value, c: greaterThanEquals}, nil
 }
 
+// NewNumericGreaterThanEqualsFloatFunc - returns new NumericGreaterThanEquals function for float value.
+func NewNumericGreaterThanEqualsFloatFunc(key Key, value float64) (Function, error) {
+	return newNumericFloatFunc(numericGreaterThanEquals, key, value, greaterThanEquals)
+}
+
 // newNumericLessThanFunc - returns new NumericLessThan function.
-func newNumericLessThanFunc(key Key, values ValueSet, _ string) (Function, error) {
-	return newNumericFunc(numericLessThan, false, key, values, lessThan)
+func newNumericLessThanFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newNumericFunc(numericLessThan, key, values, qualifier, lessThan)
 }
 
 // NewNumericLessThanFunc - returns new NumericLessThan function.
 func NewNumericLessThanFunc(key Key, value int) (Function, error) {
+	return &numericFunc{n: name{name: numericLessThan}, k: key, value: int64(value), c: lessThan}, nil
+}
+
+// NewNumericLessThanInt64Func - returns new NumericLessThan function for int64 value.
+func NewNumericLessThanInt64Func(key Key, value int64) (Function, error) {
 	return &numericFunc{n: name{name: numericLessThan}, k: key, value: value, c: lessThan}, nil
 }
 
+// NewNumericLessThanFloatFunc - returns new NumericLessThan function for float value.
+func NewNumericLessThanFloatFunc(key Key, value float64) (Function, error) {
+	return newNumericFloatFunc(numericLessThan, key, value, lessThan)
+}
+
 // newNumericLessThanEqualsFunc - returns new NumericLessThanEquals function.
-func newNumericLessThanEqualsFunc(key Key, values ValueSet, _ string) (Function, error) {
-	return newNumericFunc(numericLessThanEquals, false, key, values, lessThanEquals)
+func newNumericLessThanEqualsFunc(key Key, values ValueSet, qualifier string) (Function, error) {
+	return newNumericFunc(numericLessThanEquals, key, values, qualifier, lessThanEquals)
 }
 
 // NewNumericLessThanEqualsFunc - returns new NumericLessThanEquals function.
 func NewNumericLessThanEqualsFunc(key Key, value int) (Function, error) {
+	return &numericFunc{n: name{name: numericLessThanEquals}, k: key, value: int64(value), c: lessThanEquals}, nil
+}
+
+// NewNumericLessThanEqualsInt64Func - returns new NumericLessThanEquals function for int64 value.
+func NewNumericLessThanEqualsInt64Func(key Key, value int64) (Function, error) {
 	return &numericFunc{n: name{name: numericLessThanEquals}, k: key, value: value, c: lessThanEquals}, nil
 }
+
+// NewNumericLessThanEqualsFloatFunc - returns new NumericLessThanEquals function for float value.
+func NewNumericLessThanEqualsFloatFunc(key Key, value float64) (Function, error) {
+	return newNumericFloatFunc(numericLessThanEquals, key, value, lessThanEquals)
+}
@@ -18,6 +18,8 @@
 package condition
 
 import (
+	"encoding/json"
+	"math"
 	"reflect"
 	"testing"
 )
@@ -91,10 +93,13 @@ func TestNumericFuncEvaluate(t *testing.T) {
 		t.Fatalf("unexpected error. %v\n", err)
 	}
 
-	case7Function, err := newNumericGreaterThanIfExistsFunc(S3MaxKeys.ToKey(), valueSet, "")
+	case7Function, err := newNumericGreaterThanFunc(S3MaxKeys.ToKey(), valueSet, "")
 	if err != nil {
 		t.Fatalf("unexpected error. %v\n", err)
 	}
+	if case7Function, err = NewIfExistsFunc(case7Function); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
 
 	testNumericFuncEvaluate(t, case1Function, case2Function, case3Function, case4Function, case5Function, case6Function, case7Function)
 
@@ -344,3 +349,127 @@ func TestNumericFuncClone(t *testing.T) {
 		}
 	}
 }
+
+func TestNumericFuncDecimalEvaluate(t *testing.T) {
+	newFunc := func(value Value, fn func(Key, ValueSet, string) (Function, error)) Function {
+		f, err := fn(S3MaxKeys.ToKey(), NewValueSet(value), "")
+		if err != nil {
+			t.Fatalf("unexpected error. %v\n", err)
+		}
+		return f
+	}
+
+	floatFunc, err := NewNumericLessThanFloatFunc(S3MaxKeys.ToKey(), 10.5)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		function       Function
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{floatFunc, map[string][]string{"max-keys": {"10"}}, true},
+		{floatFunc, map[string][]string{"max-keys": {"10.49"}}, true},
+		{floatFunc, map[string][]string{"max-keys": {"10.5"}}, false},
+		{floatFunc, map[string][]string{"max-keys": {"11"}}, false},
+		{newFunc(NewStringValue("0.3"), newNumericEqualsFunc), map[string][]string{"max-keys": {"0.30"}}, true},
+		{newFunc(NewStringValue("0.3"), newNumericEqualsFunc), map[string][]string{"max-keys": {"0.30000000000000004"}}, false},
+		{newFunc(NewStringValue("2.0"), newNumericEqualsFunc), map[string][]string{"max-keys": {"2"}}, true},
+		{newFunc(NewIntValue(16), newNumericGreaterThanFunc), map[string][]string{"max-keys": {"16.001"}}, true},
+		{newFunc(NewIntValue(16), newNumericGreaterThanFunc), map[string][]string{"max-keys": {"1/2"}}, false},
+		{newFunc(NewIntValue(15), newNumericEqualsFunc), map[string][]string{"max-keys": {"1.5e1"}}, true},
+		{newFunc(NewIntValue(16), newNumericEqualsFunc), map[string][]string{"max-keys": {"0x10"}}, false},
+		{newFunc(NewIntValue(1), newNumericEqualsFunc), map[string][]string{"max-keys": {"0b1"}}, false},
+		{newFunc(NewIntValue(7), newNumericEqualsFunc), map[string][]string{"max-keys": {"0o7"}}, false},
+		{newFunc(NewIntValue(1000), newNumericEqualsFunc), map[string][]string{"max-keys": {"1_000"}}, false},
+		{newFunc(NewIntValue(0), newNumericLessThanFunc), map[string][]string{"max-keys": {"1e999999"}}, false},
+		{newFunc(NewIntValue(0), newNumericGreaterThanFunc), map[string][]string{"max-keys": {"1e999999"}}, false},
+		{newFunc(NewInt64Value(9007199254740992), newNumericLessThanFunc), map[string][]string{"max-keys": {"9007199254740993"}}, false},
+		{newFunc(NewInt64Value(9007199254740993), newNumericEqualsFunc), map[string][]string{"max-keys": {"9007199254740993"}}, true},
+		{newFunc(NewInt64Value(9007199254740993), newNumericEqualsFunc), map[string][]string{"max-keys": {"9007199254740992"}}, false},
+		{newFunc(NewStringValue("99999999999999999999"), newNumericGreaterThanFunc), map[string][]string{"max-keys": {"9223372036854775807"}}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.function.evaluate(testCase.values)
+
+		if result != testCase.expectedResult {
+			t.Errorf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	for _, value := range []float64{math.NaN(), math.Inf(1)} {
+		if _, err := NewNumericEqualsFloatFunc(S3MaxKeys.ToKey(), value); err == nil {
+			t.Fatalf("error expected for %v", value)
+		}
+	}
+}
+
+func TestNumericFuncDecimalJSON(t *testing.T) {
+	testCases := []struct {
+		data           string
+		expectedResult string
+	}{
+		{`{"NumericLessThan":{"s3:max-keys":[10.50]}}`, `{"NumericLessThan":{"s3:max-keys":[10.5]}}`},
+		{`{"NumericLessThan":{"s3:max-keys":["10.5"]}}`, `{"NumericLessThan":{"s3:max-keys":[10.5]}}`},
+		{`{"NumericEquals":{"s3:max-keys":[16]}}`, `{"NumericEquals":{"s3:max-keys":[16]}}`},
+		{`{"NumericEquals":{"s3:max-keys":["16"]}}`, `{"NumericEquals":{"s3:max-keys":[16]}}`},
+		{`{"NumericEquals":{"s3:max-keys":[9007199254740993]}}`, `{"NumericEquals":{"s3:max-keys":[9007199254740993]}}`},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		if err := json.Unmarshal([]byte(testCase.data), &functions); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+
+		data, err := json.Marshal(functions)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+
+		if string(data) != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, string(data))
+		}
+	}
+}
+
+func TestNumericFuncQualifier(t *testing.T) {
+	testCases := []struct {
+		data           string
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{`{"ForAnyValue:NumericGreaterThan":{"s3:max-keys":[10]}}`, map[string][]string{"max-keys": {"5", "20"}}, true},
+		{`{"ForAnyValue:NumericGreaterThan":{"s3:max-keys":[10]}}`, map[string][]string{"max-keys": {"5", "7"}}, false},
+		{`{"ForAnyValue:NumericGreaterThan":{"s3:max-keys":[10]}}`, map[string][]string{}, false},
+		{`{"ForAllValues:NumericGreaterThan":{"s3:max-keys":[10]}}`, map[string][]string{"max-keys": {"15", "20"}}, true},
+		{`{"ForAllValues:NumericGreaterThan":{"s3:max-keys":[10]}}`, map[string][]string{"max-keys": {"5", "20"}}, false},
+		{`{"ForAllValues:NumericGreaterThan":{"s3:max-keys":[10]}}`, map[string][]string{"max-keys": {"20", "x"}}, false},
+		{`{"ForAllValues:NumericGreaterThan":{"s3:max-keys":[10]}}`, map[string][]string{}, true},
+		{`{"ForAllValues:NumericNotEquals":{"s3:max-keys":[1.5]}}`, map[string][]string{"max-keys": {"1", "2"}}, true},
+		{`{"ForAnyValue:NumericEquals":{"s3:max-keys":[1.5]}}`, map[string][]string{"max-keys": {"1", "1.50"}}, true},
+		// Without a qualifier only the first value is checked.
+		{`{"NumericGreaterThan":{"s3:max-keys":[10]}}`, map[string][]string{"max-keys": {"5", "20"}}, false},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		if err := json.Unmarshal([]byte(testCase.data), &functions); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+
+		if result := functions.Evaluate(testCase.values); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+
+		data, err := json.Marshal(functions)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if string(data) != testCase.data {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.data, string(data))
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Operator - custom condition operator registered by RegisterOperator(). It
+// is created for the values of a condition in a policy and must be safe for
+// concurrent use.
+type Operator interface {
+	// Match - returns whether the given request value of the condition key
+	// matches the condition values.
+	Match(value string) bool
+}
+
+// NewOperator - creates the operator of a condition with the given key and
+// values, returning an error if they are not supported by the operator.
+type NewOperator func(key Key, values ValueSet) (Operator, error)
+
+var (
+	operatorsMu sync.RWMutex
+	operators   = map[string]NewOperator{}
+)
+
+// RegisterOperator - adds a condition operator of the given name, which can
+// then be used in policies like the built-in ones, including with the
+// ForAllValues and ForAnyValue qualifiers and the IfExists suffix. Like
+// StringLike, a condition matches if any request value matches, or if all
+// of them match for ForAllValues. Operators are usually registered at
+// initialization, before any policy using them is parsed.
+func RegisterOperator(operatorName string, newOperator NewOperator) error {
+	switch {
+	case operatorName == "" || strings.Contains(operatorName, ":"):
+		return fmt.Errorf("invalid condition operator name '%v'", operatorName)
+	case strings.HasSuffix(operatorName, ifExistsSuffix):
+		return fmt.Errorf("condition operator name '%v' must not end with %v", operatorName, ifExistsSuffix)
+	case newOperator == nil:
+		return fmt.Errorf("condition operator %v has no constructor", operatorName)
+	}
+
+	if _, found := names[operatorName]; found {
+		return fmt.Errorf("condition operator %v is built-in", operatorName)
+	}
+
+	operatorsMu.Lock()
+	defer operatorsMu.Unlock()
+	if _, found := operators[operatorName]; found {
+		return fmt.Errorf("condition operator %v is already registered", operatorName)
+	}
+	operators[operatorName] = newOperator
+	return nil
+}
+
+// lookupOperator - returns the constructor of the registered operator of
+// given name.
+func lookupOperator(operatorName string) (NewOperator, bool) {
+	operatorsMu.RLock()
+	defer operatorsMu.RUnlock()
+	newOperator, found := operators[operatorName]
+	return newOperator, found
+}
+
+// operatorFunc - condition function of a registered operator.
+type operatorFunc struct {
+	n        name
+	k        Key
+	values   ValueSet
+	operator Operator
+}
+
+func (f operatorFunc) evaluate(values map[string][]string) bool {
+	return matchValues(f.n.qualifier, getValuesByKey(values, f.k), f.operator.Match)
+}
+
+func (f operatorFunc) key() Key {
+	return f.k
+}
+
+func (f operatorFunc) name() name {
+	return f.n
+}
+
+func (f operatorFunc) String() string {
+	valueStrings := make([]string, 0, len(f.values))
+	for value := range f.values {
+		valueStrings = append(valueStrings, value.String())
+	}
+	sort.Strings(valueStrings)
+	return fmt.Sprintf("%v:%v:%v", f.n, f.k, valueStrings)
+}
+
+func (f operatorFunc) toMap() map[Key]ValueSet {
+	if !f.k.IsValid() {
+		return nil
+	}
+
+	return map[Key]ValueSet{
+		f.k: f.values.Clone(),
+	}
+}
+
+func (f operatorFunc) clone() Function {
+	return &operatorFunc{
+		n:        f.n,
+		k:        f.k,
+		values:   f.values.Clone(),
+		operator: f.operator,
+	}
+}
+
+func newOperatorFunc(n name, key Key, values ValueSet, newOperator NewOperator) (Function, error) {
+	operator, err := newOperator(key, values)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", n, err)
+	}
+
+	return &operatorFunc{
+		n:        name{qualifier: n.qualifier, name: n.name},
+		k:        key,
+		values:   values.Clone(),
+		operator: operator,
+	}, nil
+}
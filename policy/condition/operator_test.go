@@ -0,0 +1,164 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+)
+
+type regexOperator []*regexp.Regexp
+
+func (op regexOperator) Match(value string) bool {
+	for _, re := range op {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func newRegexOperator(_ Key, values ValueSet) (Operator, error) {
+	var op regexOperator
+	for value := range values {
+		s, err := value.GetString()
+		if err != nil {
+			return nil, err
+		}
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return nil, err
+		}
+		op = append(op, re)
+	}
+	return op, nil
+}
+
+func registerTestOperator(t *testing.T, operatorName string, newOperator NewOperator) {
+	if err := RegisterOperator(operatorName, newOperator); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	t.Cleanup(func() {
+		operatorsMu.Lock()
+		delete(operators, operatorName)
+		operatorsMu.Unlock()
+	})
+}
+
+func TestRegisterOperator(t *testing.T) {
+	registerTestOperator(t, "StringMatchesRegex", newRegexOperator)
+
+	testCases := []struct {
+		operatorName string
+		expectErr    bool
+	}{
+		{"StringMatchesRegex", true},
+		{"StringEquals", true},
+		{"ForAnyValue:StringMatchesGlob", true},
+		{"StringMatchesGlobIfExists", true},
+		{"", true},
+		{"StringMatchesGlob", false},
+	}
+
+	for i, testCase := range testCases {
+		err := RegisterOperator(testCase.operatorName, newRegexOperator)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v\n", i+1, testCase.expectErr, expectErr)
+		}
+		if err == nil {
+			operatorsMu.Lock()
+			delete(operators, testCase.operatorName)
+			operatorsMu.Unlock()
+		}
+	}
+
+	if err := RegisterOperator("StringMatchesGlob", nil); err == nil {
+		t.Fatalf("expected error for nil constructor")
+	}
+}
+
+func TestOperatorFunc(t *testing.T) {
+	registerTestOperator(t, "StringMatchesRegex", newRegexOperator)
+
+	testCases := []struct {
+		data           string
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{`{"StringMatchesRegex": {"aws:username": ["^svc-[a-z]+$"]}}`, map[string][]string{"username": {"svc-backup"}}, true},
+		{`{"StringMatchesRegex": {"aws:username": ["^svc-[a-z]+$"]}}`, map[string][]string{"username": {"alice"}}, false},
+		{`{"StringMatchesRegex": {"aws:username": ["^svc-[a-z]+$"]}}`, map[string][]string{}, false},
+		{`{"StringMatchesRegexIfExists": {"aws:username": ["^svc-[a-z]+$"]}}`, map[string][]string{}, true},
+		{`{"StringMatchesRegexIfExists": {"aws:username": ["^svc-[a-z]+$"]}}`, map[string][]string{"username": {"alice"}}, false},
+		{`{"ForAnyValue:StringMatchesRegex": {"s3:prefix": ["^logs/", "^tmp/"]}}`, map[string][]string{"prefix": {"data/", "tmp/x"}}, true},
+		{`{"ForAllValues:StringMatchesRegex": {"s3:prefix": ["^logs/", "^tmp/"]}}`, map[string][]string{"prefix": {"data/", "tmp/x"}}, false},
+		{`{"ForAllValues:StringMatchesRegex": {"s3:prefix": ["^logs/", "^tmp/"]}}`, map[string][]string{"prefix": {"logs/a", "tmp/x"}}, true},
+		{`{"ForAllValues:StringMatchesRegex": {"s3:prefix": ["^logs/", "^tmp/"]}}`, map[string][]string{}, true},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		if err := json.Unmarshal([]byte(testCase.data), &functions); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+
+		if result := functions.Evaluate(testCase.values); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+
+		data, err := json.Marshal(functions)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		var result Functions
+		if err = json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if !result.Equals(functions) || !result.Clone().Equals(functions) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, functions, result)
+		}
+	}
+}
+
+func TestOperatorFuncUnmarshalError(t *testing.T) {
+	registerTestOperator(t, "StringMatchesRegex", newRegexOperator)
+
+	testCases := []struct {
+		data      string
+		expectErr bool
+	}{
+		{`{"StringMatchesRegex": {"aws:username": ["^svc-"]}}`, false},
+		{`{"StringMatchesRegex": {"aws:username": ["("]}}`, true},
+		{`{"StringMatchesRegex": {"aws:username": [1]}}`, true},
+		{`{"StringMatchesRegex": {"aws:unknown": ["^svc-"]}}`, true},
+		{`{"StringMatchesGlob": {"aws:username": ["svc-*"]}}`, true},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		err := json.Unmarshal([]byte(testCase.data), &functions)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v\n", i+1, testCase.expectErr, expectErr)
+		}
+	}
+}
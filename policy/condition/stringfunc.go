@@ -28,15 +28,11 @@ import (
 	"github.com/minio/pkg/v3/wildcard"
 )
 
+// substitute - returns a function replacing policy variables by their values,
+// see Substitute().
 func substitute(values map[string][]string) func(string) string {
 	return func(v string) string {
-		for _, key := range CommonKeys {
-			// Empty values are not supported for policy variables.
-			if rvalues, ok := values[key.Name()]; ok && rvalues[0] != "" {
-				v = strings.ReplaceAll(v, key.VarName(), rvalues[0])
-			}
-		}
-		return v
+		return Substitute(v, values)
 	}
 }
 
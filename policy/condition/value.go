@@ -51,11 +51,12 @@ func path2BucketAndObject(path string) (bucket, object string) {
 	return bucket, object
 }
 
-// Value - is enum type of string, int or bool.
+// Value - is enum type of string, int, float or bool. Float values are kept
+// in their decimal representation to not lose precision.
 type Value struct {
 	t reflect.Kind
 	s string
-	i int
+	i int64
 	b bool
 }
 
@@ -78,9 +79,29 @@ func (v Value) GetInt() (int, error) {
 		err = fmt.Errorf("not a int Value")
 	}
 
+	return int(v.i), err
+}
+
+// GetInt64 - gets stored int value as int64.
+func (v Value) GetInt64() (int64, error) {
+	var err error
+
+	if v.t != reflect.Int {
+		err = fmt.Errorf("not a int Value")
+	}
+
 	return v.i, err
 }
 
+// GetFloat - gets stored float value.
+func (v Value) GetFloat() (float64, error) {
+	if v.t != reflect.Float64 {
+		return 0, fmt.Errorf("not a float Value")
+	}
+
+	return strconv.ParseFloat(v.s, 64)
+}
+
 // GetString - gets stored string value.
 func (v Value) GetString() (string, error) {
 	var err error
@@ -104,6 +125,8 @@ func (v Value) MarshalJSON() ([]byte, error) {
 		return json.Marshal(v.s)
 	case reflect.Int:
 		return json.Marshal(v.i)
+	case reflect.Float64:
+		return json.Marshal(json.Number(v.s))
 	case reflect.Bool:
 		return json.Marshal(v.b)
 	}
@@ -118,9 +141,24 @@ func (v *Value) StoreBool(b bool) {
 
 // StoreInt - stores int value.
 func (v *Value) StoreInt(i int) {
+	*v = Value{t: reflect.Int, i: int64(i)}
+}
+
+// StoreInt64 - stores int64 value.
+func (v *Value) StoreInt64(i int64) {
 	*v = Value{t: reflect.Int, i: i}
 }
 
+// StoreFloat - stores float value.
+func (v *Value) StoreFloat(f float64) {
+	*v = Value{t: reflect.Float64, s: strconv.FormatFloat(f, 'g', -1, 64)}
+}
+
+// storeDecimal - stores float value from its decimal representation.
+func (v *Value) storeDecimal(s string) {
+	*v = Value{t: reflect.Float64, s: s}
+}
+
 // StoreString - stores string value.
 func (v *Value) StoreString(s string) {
 	*v = Value{t: reflect.String, s: s}
@@ -132,7 +170,9 @@ func (v Value) String() string {
 	case reflect.String:
 		return v.s
 	case reflect.Int:
-		return strconv.Itoa(v.i)
+		return strconv.FormatInt(v.i, 10)
+	case reflect.Float64:
+		return v.s
 	case reflect.Bool:
 		return strconv.FormatBool(v.b)
 	}
@@ -148,12 +188,20 @@ func (v *Value) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	var i int
+	var i int64
 	if err := json.Unmarshal(data, &i); err == nil {
-		v.StoreInt(i)
+		v.StoreInt64(i)
 		return nil
 	}
 
+	var n json.Number
+	if len(data) > 0 && data[0] != '"' {
+		if err := json.Unmarshal(data, &n); err == nil {
+			v.storeDecimal(n.String())
+			return nil
+		}
+	}
+
 	var s string
 	if err := json.Unmarshal(data, &s); err == nil {
 		v.StoreString(s)
@@ -177,6 +225,20 @@ func NewIntValue(i int) Value {
 	return *value
 }
 
+// NewInt64Value - returns new int value from int64.
+func NewInt64Value(i int64) Value {
+	value := &Value{}
+	value.StoreInt64(i)
+	return *value
+}
+
+// NewFloatValue - returns new float value.
+func NewFloatValue(f float64) Value {
+	value := &Value{}
+	value.StoreFloat(f)
+	return *value
+}
+
 // NewStringValue - returns new string value.
 func NewStringValue(s string) Value {
 	value := &Value{}
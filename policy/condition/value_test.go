@@ -133,6 +133,8 @@ func TestValueMarshalJSON(t *testing.T) {
 		{NewBoolValue(true), []byte("true"), false},
 		{NewIntValue(7), []byte("7"), false},
 		{NewStringValue("foo"), []byte(`"foo"`), false},
+		{NewFloatValue(7.1), []byte("7.1"), false},
+		{NewInt64Value(9007199254740993), []byte("9007199254740993"), false},
 		{Value{}, nil, true},
 	}
 
@@ -239,7 +241,10 @@ func TestValueUnmarshalJSON(t *testing.T) {
 		{[]byte("7"), NewIntValue(7), false},
 		{[]byte(`"foo"`), NewStringValue("foo"), false},
 		{[]byte("True"), Value{}, true},
-		{[]byte("7.1"), Value{}, true},
+		{[]byte("7.1"), NewFloatValue(7.1), false},
+		{[]byte("1.50"), Value{t: reflect.Float64, s: "1.50"}, false},
+		{[]byte("9007199254740993"), NewInt64Value(9007199254740993), false},
+		{[]byte(`"7.1"`), NewStringValue("7.1"), false},
 		{[]byte(`["foo"]`), Value{}, true},
 	}
 
@@ -55,6 +55,7 @@ func TestValueSetMarshalJSON(t *testing.T) {
 		{NewValueSet(NewBoolValue(true)), `[true]`, false},
 		{NewValueSet(NewStringValue("7")), `["7"]`, false},
 		{NewValueSet(NewStringValue("foo")), `["foo"]`, false},
+		{NewValueSet(NewStringValue("foo"), NewStringValue("bar"), NewStringValue("baz")), `["bar","baz","foo"]`, false},
 		{make(ValueSet), "", true},
 	}
 
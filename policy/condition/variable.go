@@ -0,0 +1,156 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// taggedVariableKeys - condition keys usable as policy variables only with
+// a tag key, such as "${aws:PrincipalTag/team}".
+var taggedVariableKeys = map[KeyName]bool{
+	AWSPrincipalTag: true,
+}
+
+// IsVariable - returns whether the key name can be used as a policy
+// variable, i.e. "${name}", in resources, principals and condition values.
+func IsVariable(name KeyName) bool {
+	if base, tag, found := strings.Cut(string(name), "/"); found {
+		return tag != "" && taggedVariableKeys[KeyName(base)]
+	}
+	return CommonKeysMap[name]
+}
+
+// VariableKeys - returns the sorted catalogue of condition keys usable as
+// policy variables. Keys requiring a tag key are listed as "name/*".
+func VariableKeys() []KeyName {
+	keys := make([]KeyName, 0, len(CommonKeys)+len(taggedVariableKeys))
+	keys = append(keys, CommonKeys...)
+	for key := range taggedVariableKeys {
+		keys = append(keys, key+"/*")
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// nextVariable - returns the positions of the first "${...}" in s, where
+// s[start:end] is the whole variable; ok is false if there is none.
+func nextVariable(s string) (start, end int, ok bool) {
+	start = strings.Index(s, "${")
+	if start < 0 {
+		return 0, 0, false
+	}
+	end = strings.IndexByte(s[start:], '}')
+	if end < 0 {
+		return 0, 0, false
+	}
+	return start, start + end + 1, true
+}
+
+// ParseVariables - returns the key names of all "${...}" policy variables
+// in s in order of appearance, whether they are supported or not.
+func ParseVariables(s string) []KeyName {
+	var names []KeyName
+	for {
+		start, end, ok := nextVariable(s)
+		if !ok {
+			return names
+		}
+		names = append(names, KeyName(s[start+2:end-1]))
+		s = s[end:]
+	}
+}
+
+// ValidateVariables - returns an error if s contains a policy variable
+// which is not supported, see IsVariable().
+func ValidateVariables(s string) error {
+	for _, name := range ParseVariables(s) {
+		if !IsVariable(name) {
+			return fmt.Errorf("unknown policy variable '%v'", name.VarName())
+		}
+	}
+	return nil
+}
+
+// Substitute - returns s with its supported policy variables replaced by
+// the first value of their key in values. Variables which are unsupported
+// or have no value are kept as they are, as empty values are not supported
+// for policy variables.
+func Substitute(s string, values map[string][]string) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+
+	var sb strings.Builder
+	for {
+		start, end, ok := nextVariable(s)
+		if !ok {
+			break
+		}
+		sb.WriteString(s[:start])
+		name := KeyName(s[start+2 : end-1])
+		if rvalues := values[name.Name()]; IsVariable(name) && len(rvalues) > 0 && rvalues[0] != "" {
+			sb.WriteString(rvalues[0])
+		} else {
+			sb.WriteString(s[start:end])
+		}
+		s = s[end:]
+	}
+	sb.WriteString(s)
+	return sb.String()
+}
+
+// variableValue - returns the policy variable if values consist of a single
+// string which is a supported variable, such as "${aws:username}".
+func variableValue(values ValueSet) (string, bool) {
+	if len(values) != 1 {
+		return "", false
+	}
+	for v := range values {
+		s, err := v.GetString()
+		if err != nil {
+			return "", false
+		}
+		names := ParseVariables(s)
+		if len(names) == 1 && s == names[0].VarName() && IsVariable(names[0]) {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// ValidateVariables - returns an error if a condition value contains a
+// policy variable which is not supported, see IsVariable().
+func (functions Functions) ValidateVariables() error {
+	for _, f := range functions {
+		for _, values := range f.toMap() {
+			for v := range values {
+				s, err := v.GetString()
+				if err != nil {
+					continue
+				}
+				if err = ValidateVariables(s); err != nil {
+					return fmt.Errorf("%v: %w", f.name(), err)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package condition
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestIsVariable(t *testing.T) {
+	testCases := []struct {
+		name           KeyName
+		expectedResult bool
+	}{
+		{AWSUsername, true},
+		{JWTSub, true},
+		{LDAPUser, true},
+		{"aws:PrincipalTag/team", true},
+		{AWSPrincipalTag, false},
+		{"aws:PrincipalTag/", false},
+		{"aws:username/team", false},
+		{S3Prefix, false},
+		{"aws:unknown", false},
+	}
+
+	for i, testCase := range testCases {
+		result := IsVariable(testCase.name)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	// Tags of the principal are no condition keys.
+	if _, err := parseKey("aws:PrincipalTag/team"); err == nil {
+		t.Fatalf("error expected for aws:PrincipalTag/team")
+	}
+
+	keys := VariableKeys()
+	if !slices.IsSorted(keys) || !slices.Contains(keys, "aws:PrincipalTag/*") || !slices.Contains(keys, JWTSub) {
+		t.Fatalf("unexpected variable keys %v", keys)
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	values := map[string][]string{
+		"username":          {"alice"},
+		"sub":               {"1234"},
+		"user":              {"cn=alice"},
+		"PrincipalTag/team": {"blue"},
+		"groups":            {""},
+		"prefix":            {"photos"},
+	}
+	testCases := []struct {
+		s              string
+		expectedResult string
+	}{
+		{"home/${aws:username}/*", "home/alice/*"},
+		{"${jwt:sub}-${ldap:user}", "1234-cn=alice"},
+		{"teams/${aws:PrincipalTag/team}", "teams/blue"},
+		{"teams/${aws:PrincipalTag/other}", "teams/${aws:PrincipalTag/other}"},
+		{"${aws:groups}", "${aws:groups}"},
+		{"${s3:prefix}", "${s3:prefix}"},
+		{"${aws:unknown}", "${aws:unknown}"},
+		{"${aws:username", "${aws:username"},
+		{"$aws:username}", "$aws:username}"},
+		{"$${aws:username}}", "$alice}"},
+		{"no variables", "no variables"},
+	}
+
+	for i, testCase := range testCases {
+		result := Substitute(testCase.s, values)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestValidateVariables(t *testing.T) {
+	testCases := []struct {
+		s         string
+		expectErr bool
+	}{
+		{"home/${aws:username}/${jwt:sub}/*", false},
+		{"${aws:PrincipalTag/team}", false},
+		{"${aws:username", false},
+		{"${aws:unknown}", true},
+		{"${aws:PrincipalTag}", true},
+		{"${s3:prefix}", true},
+		{"${}", true},
+	}
+
+	for i, testCase := range testCases {
+		err := ValidateVariables(testCase.s)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+	}
+}
+
+func TestFunctionsVariables(t *testing.T) {
+	testCases := []struct {
+		data           string
+		values         map[string][]string
+		expectedResult bool
+		expectErr      bool
+	}{
+		{`{"NumericLessThanEquals": {"s3:max-keys": "${jwt:scope}"}}`, map[string][]string{"max-keys": {"10"}, "scope": {"20"}}, true, false},
+		{`{"NumericLessThanEquals": {"s3:max-keys": "${jwt:scope}"}}`, map[string][]string{"max-keys": {"30"}, "scope": {"20"}}, false, false},
+		{`{"NumericLessThanEquals": {"s3:max-keys": "${jwt:scope}"}}`, map[string][]string{"max-keys": {"10"}, "scope": {"all"}}, false, false},
+		{`{"NumericLessThanEquals": {"s3:max-keys": "${jwt:scope}"}}`, map[string][]string{"max-keys": {"10"}}, false, false},
+		{`{"NumericLessThanEquals": {"s3:max-keys": "${jwt:unknown}"}}`, nil, false, true},
+		{`{"DateLessThan": {"aws:CurrentTime": "${jwt:birthdate}"}}`, map[string][]string{"CurrentTime": {"2000-01-01T00:00:00Z"}, "birthdate": {"2001-01-01T00:00:00Z"}}, true, false},
+		{`{"DateLessThan": {"aws:CurrentTime": "${jwt:birthdate}"}}`, map[string][]string{"CurrentTime": {"2002-01-01T00:00:00Z"}, "birthdate": {"2001-01-01T00:00:00Z"}}, false, false},
+		{`{"IpAddress": {"aws:SourceIp": ["10.0.0.0/8", "${jwt:address}"]}}`, map[string][]string{"SourceIp": {"192.168.1.1"}, "address": {"192.168.1.0/24"}}, true, false},
+		{`{"IpAddress": {"aws:SourceIp": ["10.0.0.0/8", "${jwt:address}"]}}`, map[string][]string{"SourceIp": {"192.168.1.1"}}, false, false},
+		{`{"NotIpAddress": {"aws:SourceIp": "${jwt:address}"}}`, map[string][]string{"SourceIp": {"192.168.1.1"}, "address": {"192.168.1.1"}}, false, false},
+		{`{"IpAddress": {"aws:SourceIp": "${jwt:unknown}"}}`, nil, false, true},
+		{`{"StringEquals": {"aws:username": "${aws:PrincipalTag/owner}"}}`, map[string][]string{"username": {"alice"}, "PrincipalTag/owner": {"alice"}}, true, false},
+	}
+
+	for i, testCase := range testCases {
+		var functions Functions
+		err := json.Unmarshal([]byte(testCase.data), &functions)
+		expectErr := (err != nil)
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if expectErr {
+			continue
+		}
+
+		if result := functions.Evaluate(testCase.values); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+		if err = functions.ValidateVariables(); err != nil {
+			t.Fatalf("case %v: unexpected error. %v", i+1, err)
+		}
+
+		// Variables must survive encoding and cloning.
+		data, err := json.Marshal(functions)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v", i+1, err)
+		}
+		var decoded Functions
+		if err = json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("case %v: unexpected error. %v", i+1, err)
+		}
+		if !decoded.Equals(functions) || !functions.Clone().Equals(functions) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, functions, decoded)
+		}
+		if result := decoded.Evaluate(testCase.values); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	var functions Functions
+	if err := json.Unmarshal([]byte(`{"StringLike": {"aws:username": "${aws:userName}"}}`), &functions); err != nil {
+		t.Fatalf("unexpected error. %v", err)
+	}
+	if err := functions.ValidateVariables(); err == nil {
+		t.Fatalf("expected error for unknown variable")
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy/condition"
+	"github.com/minio/pkg/v3/wildcard"
+)
+
+// EffectivePolicy - permissions of a request made with a set of identity
+// policies, optionally restricted by a permission boundary and a session
+// policy such as the one in the SessionPolicyName claim.
+//
+// A request is denied if any of the policies denies it. Otherwise it is
+// allowed only if it is allowed by the identity policies, and by the
+// boundary and the session policy when they are set.
+type EffectivePolicy struct {
+	Identity []Policy
+	Boundary *Policy
+	Session  *Policy
+}
+
+// Decide - decides whether the given args is allowed by the effective
+// permissions. NoDecision is returned if the request is not explicitly
+// denied but not allowed by all of the policies.
+func (p EffectivePolicy) Decide(args *Args) Decision {
+	identity := NoDecision
+	for i := range p.Identity {
+		switch p.Identity[i].Decide(args) {
+		case DenyDecision:
+			return DenyDecision
+		case AllowDecision:
+			identity = AllowDecision
+		}
+	}
+
+	decision := identity
+	for _, policy := range []*Policy{p.Boundary, p.Session} {
+		if policy == nil {
+			continue
+		}
+		switch policy.Decide(args) {
+		case DenyDecision:
+			return DenyDecision
+		case NoDecision:
+			decision = NoDecision
+		}
+	}
+	return decision
+}
+
+// IsAllowed - checks whether the given args is allowed by the effective
+// permissions.
+func (p EffectivePolicy) IsAllowed(args Args) bool {
+	return p.Decide(&args) == AllowDecision
+}
+
+// IsAllowedActions - returns all supported actions allowed by the effective
+// permissions, like Policy.IsAllowedActions().
+func (p EffectivePolicy) IsAllowedActions(bucketName, objectName string, conditionValues map[string][]string) ActionSet {
+	actionSet := make(ActionSet)
+	for _, action := range supportedActionList() {
+		if p.IsAllowed(actionArgs(action, bucketName, objectName, conditionValues)) {
+			actionSet.Add(action)
+		}
+	}
+	return actionSet
+}
+
+// Explain - decides whether the given args is allowed like Decide() and
+// returns the decision along with the trace of every statement. Statements
+// of the identity policies are traced under the names "identity:<index>",
+// the others under "boundary" and "session".
+func (p EffectivePolicy) Explain(args Args) DecisionTrace {
+	trace := DecisionTrace{Decision: p.Decide(&args)}
+	for i := range p.Identity {
+		pt := p.Identity[i].explain("identity:"+strconv.Itoa(i), &args)
+		trace.Statements = append(trace.Statements, pt.Statements...)
+	}
+	if p.Boundary != nil {
+		trace.Statements = append(trace.Statements, p.Boundary.explain("boundary", &args).Statements...)
+	}
+	if p.Session != nil {
+		trace.Statements = append(trace.Statements, p.Session.explain("session", &args).Statements...)
+	}
+	return trace
+}
+
+// Intersect - returns a single policy granting the effective permissions.
+// Allow statements are intersected pairwise while all Deny statements are
+// kept. An error is returned if the intersection cannot be represented as a
+// policy, e.g. if an Allow statement uses NotAction or NotResource, or
+// overlapping resource patterns do not cover one another.
+func (p EffectivePolicy) Intersect() (Policy, error) {
+	var denies, allows []Statement
+	for _, policy := range p.Identity {
+		for _, st := range policy.Statements {
+			if st.Effect == Deny {
+				denies = append(denies, st.Clone())
+			} else {
+				allows = append(allows, st.Clone())
+			}
+		}
+	}
+
+	for _, policy := range []*Policy{p.Boundary, p.Session} {
+		if policy == nil {
+			continue
+		}
+
+		var restrict []Statement
+		for _, st := range policy.Statements {
+			if st.Effect == Deny {
+				denies = append(denies, st.Clone())
+			} else {
+				restrict = append(restrict, st)
+			}
+		}
+
+		var result []Statement
+		for _, a := range allows {
+			for _, b := range restrict {
+				st, ok, err := intersectStatements(a, b)
+				if err != nil {
+					return Policy{}, err
+				}
+				if ok {
+					result = append(result, st)
+				}
+			}
+		}
+		allows = result
+	}
+
+	policy := Policy{
+		Version:    DefaultVersion,
+		Statements: make([]Statement, 0, len(denies)+len(allows)),
+	}
+	policy.Statements = append(policy.Statements, denies...)
+	policy.Statements = append(policy.Statements, allows...)
+	policy.dropDuplicateStatements()
+	policy.updateActionIndex()
+	return policy, nil
+}
+
+// ignoresResources - returns whether resources are not matched for the
+// statement, see IsAllowedPtr().
+func (statement Statement) ignoresResources() bool {
+	return statement.isAdmin() || statement.isSTS()
+}
+
+// intersectStatements - returns the Allow statement matching requests
+// allowed by both given Allow statements; ok is false if there is none.
+func intersectStatements(a, b Statement) (st Statement, ok bool, err error) {
+	if len(a.NotActions) > 0 || len(a.NotResources) > 0 || len(b.NotActions) > 0 || len(b.NotResources) > 0 {
+		return st, false, Errorf("statements with NotAction or NotResource cannot be intersected")
+	}
+
+	st = Statement{
+		Effect:  Allow,
+		Actions: intersectActions(a.Actions, b.Actions),
+	}
+	if st.Actions.IsEmpty() {
+		return st, false, nil
+	}
+
+	switch {
+	case st.ignoresResources():
+		// The intersection only ignores resources if both statements
+		// do or match any resource.
+		for _, s := range []Statement{a, b} {
+			if !s.ignoresResources() && len(s.Resources) > 0 && !matchesAllResources(s.Resources) {
+				return st, false, Errorf("resources %v cannot be intersected with actions %v", s.Resources, st.Actions)
+			}
+		}
+	case len(a.Resources) == 0:
+		st.Resources = b.Resources.Clone()
+	case len(b.Resources) == 0:
+		st.Resources = a.Resources.Clone()
+	default:
+		if st.Resources, err = intersectResources(a.Resources, b.Resources); err != nil {
+			return st, false, err
+		}
+		if len(st.Resources) == 0 {
+			return st, false, nil
+		}
+	}
+
+	if st.Conditions, err = intersectConditions(a.Conditions, b.Conditions); err != nil {
+		return st, false, err
+	}
+	return st, true, nil
+}
+
+// intersectActions - returns the actions matched by both action sets.
+func intersectActions(a, b ActionSet) ActionSet {
+	actions := NewActionSet()
+	for x := range a {
+		for y := range b {
+			switch {
+			case !wildcard.Has(string(y)) && a.Match(y):
+				actions.Add(y)
+			case !wildcard.Has(string(x)) && b.Match(x):
+				actions.Add(x)
+			case patternCovers(string(x), string(y)):
+				actions.Add(y)
+			case patternCovers(string(y), string(x)):
+				actions.Add(x)
+			case wildcard.Has(string(x)) && wildcard.Has(string(y)):
+				// Neither pattern covers the other, use the actions
+				// matched by both.
+				for _, action := range supportedActionList() {
+					if x.Match(action) && y.Match(action) {
+						actions.Add(action)
+					}
+				}
+			}
+		}
+	}
+	return actions
+}
+
+func matchesAllResources(resources ResourceSet) bool {
+	for resource := range resources {
+		if resource.Pattern == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// intersectResources - returns the resources matched by both resource sets.
+// An error is returned if overlapping patterns do not cover one another.
+func intersectResources(a, b ResourceSet) (ResourceSet, error) {
+	resources := NewResourceSet()
+	for x := range a {
+		for y := range b {
+			switch {
+			case resourceCovers(x, y):
+				resources.Add(y)
+			case resourceCovers(y, x):
+				resources.Add(x)
+			case bucketCovered(x, y):
+				resources.Add(y)
+			case bucketCovered(y, x):
+				resources.Add(x)
+			case x.Type != y.Type && x.Type != ResourceARNAll && y.Type != ResourceARNAll:
+			case !wildcard.Has(x.Pattern) && !strings.Contains(x.Pattern, "${"),
+				!wildcard.Has(y.Pattern) && !strings.Contains(y.Pattern, "${"):
+				// A literal resource not covered by the other pattern does
+				// not overlap with it.
+			default:
+				if strings.Contains(x.Pattern, "${") || strings.Contains(y.Pattern, "${") {
+					// Policy variables are substituted at evaluation time.
+					px, py := literalPrefix(x.Pattern), literalPrefix(y.Pattern)
+					if !strings.HasPrefix(px, py) && !strings.HasPrefix(py, px) {
+						continue
+					}
+				} else if overlap, _, err := wildcard.Intersects(x.Pattern, y.Pattern); err == nil && !overlap {
+					// Patterns too complex to analyze may overlap.
+					continue
+				}
+				return nil, Errorf("resources '%v' and '%v' cannot be intersected", x, y)
+			}
+		}
+	}
+	return resources, nil
+}
+
+// bucketCovered - returns whether the pattern matches all requests matched by
+// the given bucket resource. Requests on a bucket are of the form 'bucket/',
+// see resourceName(), and match the bucket resource after path.Clean().
+func bucketCovered(pattern, resource Resource) bool {
+	if pattern.Type != resource.Type && pattern.Type != ResourceARNAll || !resource.isS3() {
+		return false
+	}
+	if wildcard.Has(resource.Pattern) || strings.ContainsAny(resource.Pattern, "/$") ||
+		strings.Contains(pattern.Pattern, "${") {
+		return false
+	}
+	return wildcard.Match(pattern.Pattern, resource.Pattern+"/")
+}
+
+// intersectConditions - returns the conditions of both given functions. An
+// error is returned if both use the same operator on the same key with
+// different values, as they cannot be written in one statement.
+func intersectConditions(a, b condition.Functions) (condition.Functions, error) {
+	functions := a.Clone()
+	descriptions := a.Describe()
+	for i, d := range b.Describe() {
+		duplicate := false
+		for _, ad := range descriptions {
+			if ad.Name != d.Name || ad.Key != d.Key {
+				continue
+			}
+			if !reflect.DeepEqual(ad.Values, d.Values) {
+				return nil, Errorf("conditions '%v' on key '%v' cannot be intersected", d.Name, d.Key)
+			}
+			duplicate = true
+		}
+		if !duplicate {
+			functions = append(functions, b[i:i+1].Clone()...)
+		}
+	}
+	return functions, nil
+}
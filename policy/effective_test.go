@@ -0,0 +1,213 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func parseTestPolicy(t *testing.T, data string) *Policy {
+	t.Helper()
+	policy, err := ParseConfig(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	return policy
+}
+
+func TestEffectivePolicyDecide(t *testing.T) {
+	identity := []Policy{
+		DefaultPolicies[0].Definition,
+		*parseTestPolicy(t, `{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["admin:ServerInfo", "admin:CreateUser"]},
+    {"Effect": "Deny", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::mybucket/locked/*"]}
+]}`),
+	}
+	boundary := parseTestPolicy(t, `{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:Get*", "s3:PutObject", "s3:DeleteObject"], "Resource": ["arn:aws:s3:::mybucket/*"]},
+    {"Effect": "Allow", "Action": ["s3:ListBucket"], "Resource": ["arn:aws:s3:::mybucket"],
+     "Condition": {"StringEquals": {"s3:prefix": "data/"}}},
+    {"Effect": "Allow", "Action": ["admin:*"]}
+]}`)
+	session := parseTestPolicy(t, `{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::mybucket/data/*", "arn:aws:s3:::mybucket"]},
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::other/*"]},
+    {"Effect": "Deny", "Action": ["admin:CreateUser"]},
+    {"Effect": "Allow", "Action": ["admin:ServerInfo"]}
+]}`)
+
+	testCases := []struct {
+		policy           EffectivePolicy
+		args             Args
+		expectedDecision Decision
+	}{
+		{EffectivePolicy{Identity: identity}, Args{Action: PutObjectAction, BucketName: "other", ObjectName: "a"}, AllowDecision},
+		{EffectivePolicy{Identity: identity, Boundary: boundary}, Args{Action: PutObjectAction, BucketName: "other", ObjectName: "a"}, NoDecision},
+		{EffectivePolicy{Identity: identity, Boundary: boundary}, Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "a"}, AllowDecision},
+		{EffectivePolicy{Identity: identity, Boundary: boundary, Session: session}, Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "a"}, NoDecision},
+		{EffectivePolicy{Identity: identity, Boundary: boundary, Session: session}, Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "data/a"}, AllowDecision},
+		{EffectivePolicy{Identity: identity, Boundary: boundary, Session: session}, Args{Action: DeleteObjectAction, BucketName: "mybucket", ObjectName: "locked/a"}, DenyDecision},
+		{EffectivePolicy{Identity: identity, Boundary: boundary, Session: session}, Args{Action: GetObjectAction, BucketName: "other", ObjectName: "a"}, NoDecision},
+		{EffectivePolicy{Identity: identity, Session: session}, Args{Action: GetObjectAction, BucketName: "other", ObjectName: "a"}, AllowDecision},
+		{EffectivePolicy{Identity: identity, Session: session}, Args{Action: CreateUserAdminAction}, DenyDecision},
+		{EffectivePolicy{Identity: identity, Boundary: boundary}, Args{Action: CreateUserAdminAction}, AllowDecision},
+		{EffectivePolicy{Identity: identity, Boundary: boundary, Session: session}, Args{Action: ServerInfoAdminAction}, AllowDecision},
+		{EffectivePolicy{Boundary: boundary, Session: session}, Args{Action: ServerInfoAdminAction}, NoDecision},
+	}
+
+	for i, testCase := range testCases {
+		if decision := testCase.policy.Decide(&testCase.args); decision != testCase.expectedDecision {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedDecision, decision)
+		}
+		if trace := testCase.policy.Explain(testCase.args); trace.Decision != testCase.expectedDecision {
+			t.Fatalf("case %v: explain: expected: %v, got: %v", i+1, testCase.expectedDecision, trace.Decision)
+		}
+	}
+
+	p := EffectivePolicy{Identity: identity, Boundary: boundary, Session: session}
+	actions := p.IsAllowedActions("mybucket", "data/a", nil)
+	for _, action := range []Action{GetObjectAction, PutObjectAction, DeleteObjectAction, ServerInfoAdminAction} {
+		if !actions.Contains(action) {
+			t.Fatalf("expected action %v to be allowed, got %v", action, actions)
+		}
+	}
+	for _, action := range []Action{ListBucketAction, CreateUserAdminAction, KMSCreateKeyAction} {
+		if actions.Contains(action) {
+			t.Fatalf("expected action %v not to be allowed, got %v", action, actions)
+		}
+	}
+
+	merged, err := p.Intersect()
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	if err = merged.Validate(); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	resources := [][2]string{
+		{"mybucket", ""}, {"mybucket", "a"}, {"mybucket", "data/a"}, {"mybucket", "locked/a"},
+		{"other", "a"}, {"", ""},
+	}
+	conditions := []map[string][]string{
+		nil,
+		{"prefix": {"data/"}},
+	}
+	for _, action := range supportedActionList() {
+		for _, resource := range resources {
+			for _, conds := range conditions {
+				args := Args{Action: action, BucketName: resource[0], ObjectName: resource[1], ConditionValues: conds}
+				if expected, got := p.IsAllowed(args), merged.IsAllowed(args); expected != got {
+					t.Fatalf("%v on %v with %v: expected: %v, got: %v", action, resource, conds, expected, got)
+				}
+			}
+		}
+	}
+}
+
+func TestEffectivePolicyIntersect(t *testing.T) {
+	testCases := []struct {
+		identity       string
+		boundary       string
+		expectedResult string
+		expectErr      bool
+	}{
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:Get*"], "Resource": ["arn:aws:s3:::mybucket/*"]}]}`,
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:*Object"], "Resource": ["arn:aws:s3:::mybucket/data/*"]}]}`,
+			`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::mybucket/data/*"]}]}`,
+			false,
+		},
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}]}`,
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::other/*"]}]}`,
+			`{"Version":"2012-10-17","Statement":[]}`,
+			false,
+		},
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"],
+"Condition": {"StringEquals": {"aws:username": "alice"}}}]}`,
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::*"],
+"Condition": {"IpAddress": {"aws:SourceIp": "192.168.1.0/24"}}}]}`,
+			`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::mybucket/*"],"Condition":{"IpAddress":{"aws:SourceIp":["192.168.1.0/24"]},"StringEquals":{"aws:username":["alice"]}}}]}`,
+			false,
+		},
+		// same condition with different values.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"],
+"Condition": {"StringEquals": {"aws:username": "alice"}}}]}`,
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"],
+"Condition": {"StringEquals": {"aws:username": "bob"}}}]}`,
+			``,
+			true,
+		},
+		// overlapping resources.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/a*"]}]}`,
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*b"]}]}`,
+			``,
+			true,
+		},
+		// disjoint resource patterns.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*.jpg"]}]}`,
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*.png"]}]}`,
+			`{"Version":"2012-10-17","Statement":[]}`,
+			false,
+		},
+		// resource patterns too complex to analyze may overlap.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*a????????????????????????????????b"]}]}`,
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*c"]}]}`,
+			``,
+			true,
+		},
+		// NotAction.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "NotAction": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}]}`,
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}]}`,
+			``,
+			true,
+		},
+	}
+
+	for i, testCase := range testCases {
+		p := EffectivePolicy{
+			Identity: []Policy{*parseTestPolicy(t, testCase.identity)},
+			Boundary: parseTestPolicy(t, testCase.boundary),
+		}
+		result, err := p.Intersect()
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v, %v", i+1, testCase.expectErr, expectErr, err)
+		}
+
+		if !testCase.expectErr {
+			data, err := json.Marshal(result)
+			if err != nil {
+				t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+			}
+			if string(data) != testCase.expectedResult {
+				t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, string(data))
+			}
+		}
+	}
+}
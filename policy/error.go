@@ -18,19 +18,65 @@
 package policy
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy/condition"
+	"github.com/minio/pkg/v3/quick"
 )
 
 // Error is the generic type for any error happening during policy
 // parsing.
 type Error struct {
 	err error
+
+	// Statement is the index of the statement causing the error in the
+	// parsed document, -1 if unknown.
+	Statement int
+	// Path is the JSON field path of the element causing the error, such
+	// as "Statement[3].Condition.StringLike.s3:prefix", empty if unknown.
+	Path string
+	// Line and Column are the position of the element causing the error
+	// in the parsed document, starting at 1, zero if unknown.
+	Line   int
+	Column int
 }
 
 // Errorf - formats according to a format specifier and returns
 // the string as a value that satisfies error of type policy.Error
 func Errorf(format string, a ...interface{}) error {
-	return Error{err: fmt.Errorf(format, a...)}
+	return Error{err: fmt.Errorf(format, a...), Statement: -1}
+}
+
+// fieldError - an error caused by the value of a statement field.
+type fieldError struct {
+	field string
+	err   error
+}
+
+func (e fieldError) Error() string { return e.err.Error() }
+
+func (e fieldError) Unwrap() error { return e.err }
+
+// fieldErrorf - like Errorf(), for an error caused by the given field of a
+// statement.
+func fieldErrorf(field, format string, a ...interface{}) error {
+	return Error{err: fieldError{field: field, err: fmt.Errorf(format, a...)}, Statement: -1}
+}
+
+// errorField - returns the statement field causing err, or an empty string
+// if no single field is to blame.
+func errorField(err error) string {
+	var ferr fieldError
+	if errors.As(err, &ferr) {
+		return ferr.field
+	}
+	return ""
 }
 
 // Unwrap the internal error.
@@ -41,5 +87,228 @@ func (e Error) Error() string {
 	if e.err == nil {
 		return "policy: cause <nil>"
 	}
-	return e.err.Error()
+	msg := e.err.Error()
+	if e.Path != "" {
+		msg = e.Path + ": " + msg
+	}
+	if e.Line > 0 {
+		msg += fmt.Sprintf(" (line %d, column %d)", e.Line, e.Column)
+	}
+	return msg
+}
+
+// pathElement - a field name or an array index in a JSON document.
+type pathElement struct {
+	key   string
+	index int
+}
+
+type jsonPath []pathElement
+
+func (p jsonPath) String() string {
+	var sb strings.Builder
+	for _, elem := range p {
+		if elem.key == "" {
+			sb.WriteString("[" + strconv.Itoa(elem.index) + "]")
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(elem.key)
+	}
+	return sb.String()
+}
+
+// offset - returns the offset of the value at the path in data, or -1 if
+// it is not found.
+func (p jsonPath) offset(data []byte) int64 {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for _, elem := range p {
+		token, err := decoder.Token()
+		if err != nil {
+			return -1
+		}
+
+		found := false
+		switch {
+		case elem.key != "" && token == json.Delim('{'):
+			for !found && decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return -1
+				}
+				if found = key == elem.key; !found {
+					var value json.RawMessage
+					if err = decoder.Decode(&value); err != nil {
+						return -1
+					}
+				}
+			}
+		case elem.key == "" && token == json.Delim('['):
+			for i := 0; i < elem.index && decoder.More(); i++ {
+				var value json.RawMessage
+				if err = decoder.Decode(&value); err != nil {
+					return -1
+				}
+			}
+			found = decoder.More()
+		}
+		if !found {
+			return -1
+		}
+	}
+
+	// Skip the separators preceding the value.
+	offset := decoder.InputOffset()
+	for offset < int64(len(data)) && bytes.IndexByte([]byte(" \t\r\n:,"), data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// findKey - returns the key of the object matching name case-insensitively
+// like encoding/json does for struct fields.
+func findKey(object map[string]json.RawMessage, name string) (string, bool) {
+	if _, ok := object[name]; ok {
+		return name, true
+	}
+	for key := range object {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// statement - a statement type of a policy document.
+type statement interface {
+	Statement | BPStatement
+}
+
+// locateStatementError - returns the path of the element causing the given
+// statement to be invalid with cause, relative to the statement. An empty
+// path is returned if no single element can be blamed.
+func locateStatementError[T statement](data []byte, cause error) jsonPath {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+
+	for _, key := range sortedKeys(fields) {
+		field, err := json.Marshal(map[string]json.RawMessage{key: fields[key]})
+		if err != nil {
+			continue
+		}
+		var st T
+		if err = json.Unmarshal(field, &st); err == nil {
+			continue
+		}
+
+		path := jsonPath{{key: key}}
+		if !strings.EqualFold(key, "Condition") {
+			return path
+		}
+
+		var operators map[string]map[string]json.RawMessage
+		if err = json.Unmarshal(fields[key], &operators); err != nil {
+			return path
+		}
+		for _, name := range sortedKeys(operators) {
+			for _, conditionKey := range sortedKeys(operators[name]) {
+				function, err := json.Marshal(map[string]map[string]json.RawMessage{
+					name: {conditionKey: operators[name][conditionKey]},
+				})
+				if err != nil {
+					continue
+				}
+				var functions condition.Functions
+				if err = json.Unmarshal(function, &functions); err != nil {
+					return append(path, pathElement{key: name}, pathElement{key: conditionKey})
+				}
+			}
+		}
+		return path
+	}
+
+	// The statement is decoded but invalid.
+	if key, ok := findKey(fields, errorField(cause)); ok {
+		return jsonPath{{key: key}}
+	}
+	return nil
+}
+
+// locateError - returns the given error of parsing the policy document data
+// annotated with the location of the element causing it. Statements are
+// checked one by one with checkStatement, which decodes and validates a
+// single statement of type T.
+func locateError[T statement](data []byte, err error, checkStatement func(data []byte) error) error {
+	var perr Error
+	if errors.As(err, &perr) && perr.Path != "" {
+		return err
+	}
+	if e, ok := err.(Error); ok && e.err != nil {
+		err = e.err
+	}
+	result := Error{err: err, Statement: -1}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		if syntaxErr.Offset > 0 {
+			result.Line, result.Column = quick.JSONPosition(bytes.NewReader(data), syntaxErr.Offset-1)
+		}
+		return result
+	}
+
+	var path jsonPath
+	var document map[string]json.RawMessage
+	if json.Unmarshal(data, &document) == nil {
+		path = locateDocumentError[T](document, checkStatement)
+		if len(path) > 1 {
+			result.Statement = path[1].index
+		}
+	}
+
+	if offset := path.offset(data); offset >= 0 {
+		result.Path = path.String()
+		result.Line, result.Column = quick.JSONPosition(bytes.NewReader(data), offset)
+	}
+	return result
+}
+
+func locateDocumentError[T statement](document map[string]json.RawMessage, checkStatement func(data []byte) error) jsonPath {
+	if key, ok := findKey(document, "Version"); ok {
+		var version string
+		if err := json.Unmarshal(document[key], &version); err != nil ||
+			version != DefaultVersion && version != "" {
+			return jsonPath{{key: key}}
+		}
+	}
+
+	key, ok := findKey(document, "Statement")
+	if !ok {
+		return nil
+	}
+	var statements []json.RawMessage
+	if err := json.Unmarshal(document[key], &statements); err != nil {
+		return jsonPath{{key: key}}
+	}
+	for i, statement := range statements {
+		err := checkStatement(statement)
+		if err == nil {
+			continue
+		}
+		path := jsonPath{{key: key}, {index: i}}
+		return append(path, locateStatementError[T](statement, err)...)
+	}
+	return nil
 }
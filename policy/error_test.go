@@ -0,0 +1,104 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseConfigErrorLocation(t *testing.T) {
+	testCases := []struct {
+		data              string
+		expectedStatement int
+		expectedPath      string
+		expectedLine      int
+		expectedColumn    int
+	}{
+		// syntax error.
+		{`{"Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow" "Action": ["s3:GetObject"]}
+  ]
+}`, -1, "", 3, 24},
+		// invalid version.
+		{`{"Version": "2020-01-01", "Statement": []}`, -1, "Version", 1, 13},
+		// invalid action.
+		{`{"Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"]},
+    {"Effect": "Allow",
+     "Action": ["s3:GetObjects"],
+     "Resource": ["arn:aws:s3:::mybucket/*"]}
+  ]
+}`, 1, "Statement[1].Action", 5, 16},
+		// invalid condition value.
+		{`{"Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Action": ["s3:ListBucket"], "Resource": ["arn:aws:s3:::mybucket"],
+     "Condition": {"StringLike": {"s3:prefix": ["a"]}, "NumericLessThan": {"s3:max-keys": "ten"}}}
+  ]
+}`, 0, "Statement[0].Condition.NumericLessThan.s3:max-keys", 4, 91},
+		// invalid statement.
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"]}]}`, 0, "Statement[0]", 1, 41},
+		// condition key not supported by the action.
+		{`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"], "Condition": {"StringEquals": {"s3:prefix": "a"}}}]}`, 0, "Statement[0].Condition", 1, 143},
+		// invalid effect in a lower case field.
+		{`{"Version": "2012-10-17", "statement": [{"effect": "Maybe", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}]}`, 0, "statement[0].effect", 1, 52},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.data))
+		var perr Error
+		if !errors.As(err, &perr) {
+			t.Fatalf("case %v: expected policy error, got: %v", i+1, err)
+		}
+
+		if perr.Statement != testCase.expectedStatement || perr.Path != testCase.expectedPath ||
+			perr.Line != testCase.expectedLine || perr.Column != testCase.expectedColumn {
+			t.Fatalf("case %v: expected: %v %v %v:%v, got: %v %v %v:%v (%v)", i+1,
+				testCase.expectedStatement, testCase.expectedPath, testCase.expectedLine, testCase.expectedColumn,
+				perr.Statement, perr.Path, perr.Line, perr.Column, err)
+		}
+	}
+}
+
+func TestParseBucketPolicyConfigErrorLocation(t *testing.T) {
+	data := `{"Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Principal": "*", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"]},
+    {"Effect": "Allow", "Principal": "*", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::otherbucket/*"]}
+  ]
+}`
+	_, err := ParseBucketPolicyConfig(strings.NewReader(data), "mybucket")
+	var perr Error
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected policy error, got: %v", err)
+	}
+	if perr.Statement != 1 || perr.Path != "Statement[1].Resource" || perr.Line != 4 || perr.Column != 83 {
+		t.Fatalf("unexpected error location %v", err)
+	}
+	if err.Error() != "Statement[1].Resource: bucket name does not match (line 4, column 83)" {
+		t.Fatalf("unexpected error message %v", err)
+	}
+
+	if err = Errorf("invalid action"); err.Error() != "invalid action" {
+		t.Fatalf("unexpected error message %v", err)
+	}
+}
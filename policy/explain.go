@@ -0,0 +1,261 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// StatementTrace - records how a single statement was evaluated for a request.
+type StatementTrace struct {
+	// Policy is the name of the policy the statement belongs to, if known.
+	Policy string `json:"policy,omitempty"`
+	// Index is the position of the statement in the policy.
+	Index  int    `json:"index"`
+	SID    ID     `json:"sid,omitempty"`
+	Effect Effect `json:"effect"`
+
+	// PrincipalMatched is true if the Principal element matched or the
+	// NotPrincipal element did not match the request account. It is always
+	// true for IAM policy statements, which have no principal.
+	PrincipalMatched bool `json:"principalMatched"`
+	ActionMatched    bool `json:"actionMatched"`
+	// ResourceMatched is true if the Resource element matched the request
+	// resource, or if the statement has no Resource element or ignores it.
+	ResourceMatched bool `json:"resourceMatched"`
+	// NotResourceMatched is true if the NotResource element matched the
+	// request resource, which excludes the statement.
+	NotResourceMatched bool               `json:"notResourceMatched"`
+	Conditions         []condition.Result `json:"conditions,omitempty"`
+	ConditionsMatched  bool               `json:"conditionsMatched"`
+
+	// Matched is true if the statement applies to the request, i.e. its
+	// effect took part in the decision.
+	Matched bool `json:"matched"`
+}
+
+// DecisionTrace - decision made for a request along with the trace of every
+// evaluated statement.
+type DecisionTrace struct {
+	Decision   Decision         `json:"decision"`
+	Statements []StatementTrace `json:"statements"`
+}
+
+// Deciding - returns the statements which led to the decision, i.e. all
+// matched Deny statements for a DenyDecision and all matched Allow
+// statements for an AllowDecision.
+func (t DecisionTrace) Deciding() []StatementTrace {
+	var effect Effect
+	switch t.Decision {
+	case AllowDecision:
+		effect = Allow
+	case DenyDecision:
+		effect = Deny
+	default:
+		return nil
+	}
+
+	var statements []StatementTrace
+	for _, st := range t.Statements {
+		if st.Matched && st.Effect == effect {
+			statements = append(statements, st)
+		}
+	}
+	return statements
+}
+
+func explainConditions(trace *StatementTrace, conditions condition.Functions, values map[string][]string) {
+	trace.Conditions = conditions.Explain(values)
+	trace.ConditionsMatched = true
+	for _, result := range trace.Conditions {
+		if !result.Matched {
+			trace.ConditionsMatched = false
+		}
+	}
+}
+
+// explain - evaluates the statement the same way as IsAllowedPtr() without
+// short-circuiting, recording the outcome of each element.
+func (statement Statement) explain(args *Args) StatementTrace {
+	trace := StatementTrace{
+		SID:              statement.SID,
+		Effect:           statement.Effect,
+		PrincipalMatched: true,
+		ResourceMatched:  true,
+	}
+
+	trace.ActionMatched = (statement.Actions.IsEmpty() || statement.Actions.Match(args.Action)) &&
+		!statement.NotActions.Match(args.Action)
+
+	resource := resourceName(args.BucketName, args.ObjectName)
+	switch {
+	case statement.isKMS() && (resource == "/" || len(statement.Resources) == 0):
+		// KMS statements ignore resources in these cases, see IsAllowedPtr().
+	case statement.isAdmin() || statement.isSTS():
+		// For some admin statements, resource match is ignored.
+	default:
+		if len(statement.Resources) > 0 {
+			trace.ResourceMatched = statement.Resources.Match(resource, args.ConditionValues)
+		}
+		if len(statement.NotResources) > 0 {
+			trace.NotResourceMatched = statement.NotResources.Match(resource, args.ConditionValues)
+		}
+	}
+
+	explainConditions(&trace, statement.Conditions, args.ConditionValues)
+
+	trace.Matched = trace.ActionMatched && trace.ResourceMatched &&
+		!trace.NotResourceMatched && trace.ConditionsMatched
+	return trace
+}
+
+// Explain - decides whether the given args is allowed like Decide() and
+// returns the decision along with the trace of every statement in the
+// policy.
+func (iamp *Policy) Explain(args *Args) DecisionTrace {
+	return iamp.explain("", args)
+}
+
+func (iamp *Policy) explain(name string, args *Args) DecisionTrace {
+	trace := DecisionTrace{
+		Decision:   NoDecision,
+		Statements: make([]StatementTrace, 0, len(iamp.Statements)),
+	}
+
+	var denied, allowed bool
+	for i, statement := range iamp.Statements {
+		st := statement.explain(args)
+		st.Policy = name
+		st.Index = i
+		if st.Matched {
+			switch statement.Effect {
+			case Deny:
+				denied = true
+			case Allow:
+				allowed = true
+			}
+		}
+		trace.Statements = append(trace.Statements, st)
+	}
+
+	switch {
+	case denied:
+		trace.Decision = DenyDecision
+	case args.DenyOnly, args.IsOwner, allowed:
+		trace.Decision = AllowDecision
+	}
+	return trace
+}
+
+// ExplainPolicies - decides whether the given args is allowed by the given
+// named policies like IsAllowedSerial() and returns the decision along with
+// the trace of every statement, ordered by policy name.
+func ExplainPolicies(policies map[string]Policy, args Args) DecisionTrace {
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	trace := DecisionTrace{Decision: NoDecision}
+	for _, name := range names {
+		policy := policies[name]
+		pt := policy.explain(name, &args)
+		trace.Statements = append(trace.Statements, pt.Statements...)
+		switch {
+		case pt.Decision == DenyDecision:
+			trace.Decision = DenyDecision
+		case pt.Decision == AllowDecision && trace.Decision == NoDecision:
+			trace.Decision = AllowDecision
+		}
+	}
+	return trace
+}
+
+// explain - evaluates the statement the same way as IsAllowed() without
+// short-circuiting, recording the outcome of each element.
+func (statement BPStatement) explain(args BucketPolicyArgs) StatementTrace {
+	trace := StatementTrace{
+		SID:             statement.SID,
+		Effect:          statement.Effect,
+		ResourceMatched: true,
+	}
+
+	trace.PrincipalMatched = statement.matchPrincipal(args.AccountName, args.ConditionValues)
+
+	trace.ActionMatched = (statement.Actions.IsEmpty() || statement.Actions.Match(args.Action)) &&
+		!statement.NotActions.Match(args.Action)
+
+	resource := args.BucketName
+	if args.ObjectName != "" {
+		if !strings.HasPrefix(args.ObjectName, "/") {
+			resource += "/"
+		}
+		resource += args.ObjectName
+	}
+
+	if len(statement.Resources) > 0 {
+		trace.ResourceMatched = statement.Resources.Match(resource, args.ConditionValues)
+	}
+	if len(statement.NotResources) > 0 {
+		trace.NotResourceMatched = statement.NotResources.Match(resource, args.ConditionValues)
+	}
+
+	explainConditions(&trace, statement.Conditions, args.ConditionValues)
+
+	trace.Matched = trace.PrincipalMatched && trace.ActionMatched && trace.ResourceMatched &&
+		!trace.NotResourceMatched && trace.ConditionsMatched
+	return trace
+}
+
+// Explain - checks whether the given args is allowed like IsAllowed() and
+// returns the decision along with the trace of every statement in the bucket
+// policy. NoDecision is returned when no statement applies and the request
+// is not from the owner.
+func (policy BucketPolicy) Explain(args BucketPolicyArgs) DecisionTrace {
+	trace := DecisionTrace{
+		Decision:   NoDecision,
+		Statements: make([]StatementTrace, 0, len(policy.Statements)),
+	}
+
+	var denied, allowed bool
+	for i, statement := range policy.Statements {
+		st := statement.explain(args)
+		st.Index = i
+		if st.Matched {
+			switch statement.Effect {
+			case Deny:
+				denied = true
+			case Allow:
+				allowed = true
+			}
+		}
+		trace.Statements = append(trace.Statements, st)
+	}
+
+	switch {
+	case denied:
+		trace.Decision = DenyDecision
+	case args.IsOwner, allowed:
+		trace.Decision = AllowDecision
+	}
+	return trace
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"net"
+	"testing"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+func TestPolicyExplain(t *testing.T) {
+	_, IPNet1, err := net.ParseCIDR("192.168.1.0/24")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	func1, err := condition.NewIPAddressFunc(
+		condition.AWSSourceIP.ToKey(),
+		IPNet1,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	policy := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement("AllowRead",
+				Allow,
+				NewActionSet(GetObjectAction, GetBucketLocationAction),
+				NewResourceSet(NewResource("mybucket/*"), NewResource("mybucket")),
+				condition.NewFunctions(),
+			),
+			NewStatement("AllowWriteFromLAN",
+				Allow,
+				NewActionSet(PutObjectAction),
+				NewResourceSet(NewResource("mybucket/*")),
+				condition.NewFunctions(func1),
+			),
+			NewStatementWithNotResource("DenyOutsideMyBucket",
+				Deny,
+				NewActionSet(GetObjectAction, PutObjectAction),
+				NewResourceSet(NewResource("mybucket/*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+	policy.updateActionIndex()
+
+	testCases := []struct {
+		args             Args
+		expectedDecision Decision
+		expectedDeciding []int
+	}{
+		{
+			Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"},
+			AllowDecision, []int{0},
+		},
+		{
+			Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject", ConditionValues: map[string][]string{"SourceIp": {"192.168.1.10"}}},
+			AllowDecision, []int{1},
+		},
+		{
+			Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject", ConditionValues: map[string][]string{"SourceIp": {"10.1.1.10"}}},
+			NoDecision, nil,
+		},
+		{
+			Args{Action: GetObjectAction, BucketName: "otherbucket", ObjectName: "myobject"},
+			DenyDecision, []int{2},
+		},
+		{
+			Args{Action: GetObjectAction, BucketName: "otherbucket", ObjectName: "myobject", IsOwner: true},
+			DenyDecision, []int{2},
+		},
+		{
+			Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject", DenyOnly: true},
+			AllowDecision, nil,
+		},
+	}
+
+	for i, testCase := range testCases {
+		trace := policy.Explain(&testCase.args)
+		if trace.Decision != testCase.expectedDecision {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedDecision, trace.Decision)
+		}
+		if decision := policy.Decide(&testCase.args); decision != trace.Decision {
+			t.Fatalf("case %v: explain decision %v does not agree with Decide() %v", i+1, trace.Decision, decision)
+		}
+		if len(trace.Statements) != len(policy.Statements) {
+			t.Fatalf("case %v: expected: %v statements, got: %v", i+1, len(policy.Statements), len(trace.Statements))
+		}
+
+		deciding := trace.Deciding()
+		if len(deciding) != len(testCase.expectedDeciding) {
+			t.Fatalf("case %v: expected deciding statements: %v, got: %v", i+1, testCase.expectedDeciding, deciding)
+		}
+		for j, st := range deciding {
+			if st.Index != testCase.expectedDeciding[j] {
+				t.Fatalf("case %v: expected deciding statements: %v, got: %v", i+1, testCase.expectedDeciding, deciding)
+			}
+		}
+	}
+
+	// Check reported reasons for a failed request.
+	trace := policy.Explain(&testCases[2].args)
+	st := trace.Statements[1]
+	if !st.ActionMatched || !st.ResourceMatched || st.ConditionsMatched || st.Matched {
+		t.Fatalf("unexpected trace %+v", st)
+	}
+	if len(st.Conditions) != 1 || st.Conditions[0].Name != "IpAddress" || st.Conditions[0].Matched {
+		t.Fatalf("unexpected condition trace %+v", st.Conditions)
+	}
+
+	trace = policy.Explain(&testCases[3].args)
+	if st := trace.Statements[2]; !st.ActionMatched || st.NotResourceMatched || !st.Matched {
+		t.Fatalf("unexpected trace %+v", st)
+	}
+	if st := trace.Statements[0]; !st.ActionMatched || st.ResourceMatched || st.Matched {
+		t.Fatalf("unexpected trace %+v", st)
+	}
+}
+
+func TestExplainPolicies(t *testing.T) {
+	readOnly := DefaultPolicies[1].Definition
+	writeOnly := DefaultPolicies[2].Definition
+	denyPut := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement("",
+				Deny,
+				NewActionSet(PutObjectAction),
+				NewResourceSet(NewResource("secret/*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+
+	policies := map[string]Policy{
+		"readonly":  readOnly,
+		"writeonly": writeOnly,
+		"denyput":   denyPut,
+	}
+
+	testCases := []struct {
+		args             Args
+		expectedDecision Decision
+	}{
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, AllowDecision},
+		{Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, AllowDecision},
+		{Args{Action: PutObjectAction, BucketName: "secret", ObjectName: "myobject"}, DenyDecision},
+		{Args{Action: DeleteObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, NoDecision},
+	}
+
+	list := []Policy{readOnly, writeOnly, denyPut}
+	for i, testCase := range testCases {
+		trace := ExplainPolicies(policies, testCase.args)
+		if trace.Decision != testCase.expectedDecision {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedDecision, trace.Decision)
+		}
+		if allowed := IsAllowedSerial(list, testCase.args); allowed != (trace.Decision == AllowDecision) {
+			t.Fatalf("case %v: explain does not agree with IsAllowedSerial()", i+1)
+		}
+	}
+
+	trace := ExplainPolicies(policies, testCases[2].args)
+	deciding := trace.Deciding()
+	if len(deciding) != 1 || deciding[0].Policy != "denyput" || deciding[0].Index != 0 {
+		t.Fatalf("unexpected deciding statements %+v", deciding)
+	}
+	if trace.Statements[0].Policy != "denyput" {
+		t.Fatalf("expected statements to be ordered by policy name, got %v first", trace.Statements[0].Policy)
+	}
+}
+
+func TestBucketPolicyExplain(t *testing.T) {
+	policy := BucketPolicy{
+		Version: DefaultVersion,
+		Statements: []BPStatement{
+			NewBPStatement("",
+				Allow,
+				NewPrincipal("*"),
+				NewActionSet(GetObjectAction),
+				NewResourceSet(NewResource("mybucket/*")),
+				condition.NewFunctions(),
+			),
+			NewBPStatement("",
+				Deny,
+				NewPrincipal("Q3AM3UQ867SPQQA43P2F"),
+				NewActionSet(GetObjectAction),
+				NewResourceSet(NewResource("mybucket/private/*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+
+	testCases := []struct {
+		args             BucketPolicyArgs
+		expectedDecision Decision
+	}{
+		{BucketPolicyArgs{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, AllowDecision},
+		{BucketPolicyArgs{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "private/myobject"}, DenyDecision},
+		{BucketPolicyArgs{AccountName: "anybody", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "private/myobject"}, AllowDecision},
+		{BucketPolicyArgs{AccountName: "anybody", Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, NoDecision},
+		{BucketPolicyArgs{AccountName: "anybody", Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject", IsOwner: true}, AllowDecision},
+	}
+
+	for i, testCase := range testCases {
+		trace := policy.Explain(testCase.args)
+		if trace.Decision != testCase.expectedDecision {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedDecision, trace.Decision)
+		}
+		if allowed := policy.IsAllowed(testCase.args); allowed != (trace.Decision == AllowDecision) {
+			t.Fatalf("case %v: explain does not agree with IsAllowed()", i+1)
+		}
+	}
+
+	trace := policy.Explain(testCases[2].args)
+	if st := trace.Statements[1]; st.PrincipalMatched || !st.ActionMatched || !st.ResourceMatched || st.Matched {
+		t.Fatalf("unexpected trace %+v", st)
+	}
+}
+
+func TestDecisionText(t *testing.T) {
+	for _, d := range []Decision{NoDecision, AllowDecision, DenyDecision} {
+		text, err := d.MarshalText()
+		if err != nil {
+			t.Fatalf("unexpected error. %v\n", err)
+		}
+		var got Decision
+		if err = got.UnmarshalText(text); err != nil {
+			t.Fatalf("unexpected error. %v\n", err)
+		}
+		if got != d {
+			t.Fatalf("expected: %v, got: %v", d, got)
+		}
+	}
+
+	var d Decision
+	if err := d.UnmarshalText([]byte("maybe")); err == nil {
+		t.Fatalf("expected error for invalid decision")
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"sort"
+
+	"github.com/minio/pkg/v3/policy/condition"
+	"github.com/zeebo/xxh3"
+)
+
+// fingerprintSeed - fixed seed of fingerprints, it must never change as
+// fingerprints are persisted.
+const fingerprintSeed = 0x6d696e696f2d6670
+
+// Fingerprint - stable content hash of a policy or statement, suitable for
+// cache keys and for detecting changes across nodes and restarts. It does
+// not depend on the order of statements, actions, resources, principals and
+// conditions, and ignores the ID and SID elements as they do not affect
+// evaluation.
+type Fingerprint [16]byte
+
+// String - returns the hex encoding of the fingerprint.
+func (f Fingerprint) String() string {
+	return hex.EncodeToString(f[:])
+}
+
+// MarshalText - encodes Fingerprint to its hex representation.
+func (f Fingerprint) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText - decodes Fingerprint from its hex representation.
+func (f *Fingerprint) UnmarshalText(text []byte) error {
+	var fp Fingerprint
+	if hex.DecodedLen(len(text)) != len(fp) {
+		return Errorf("invalid fingerprint '%s'", text)
+	}
+	if _, err := hex.Decode(fp[:], text); err != nil {
+		return Errorf("invalid fingerprint '%s'", text)
+	}
+	*f = fp
+	return nil
+}
+
+// fingerprinter - builds the canonical encoding of an element, which is
+// hashed to a fingerprint. Every field is written as a tag followed by the
+// length prefixed and sorted values, so that distinct elements cannot have
+// the same encoding.
+type fingerprinter struct {
+	buf bytes.Buffer
+}
+
+func (f *fingerprinter) writeString(s string) {
+	var tmp [binary.MaxVarintLen64]byte
+	f.buf.Write(tmp[:binary.PutUvarint(tmp[:], uint64(len(s)))])
+	f.buf.WriteString(s)
+}
+
+func (f *fingerprinter) field(tag string, values ...string) {
+	sort.Strings(values)
+	f.writeString(tag)
+	var tmp [binary.MaxVarintLen64]byte
+	f.buf.Write(tmp[:binary.PutUvarint(tmp[:], uint64(len(values)))])
+	for _, value := range values {
+		f.writeString(value)
+	}
+}
+
+func (f *fingerprinter) actions(tag string, actions ActionSet) {
+	values := make([]string, 0, len(actions))
+	for action := range actions {
+		values = append(values, string(action))
+	}
+	f.field(tag, values...)
+}
+
+func (f *fingerprinter) resources(tag string, resources ResourceSet) {
+	values := make([]string, 0, len(resources))
+	for resource := range resources {
+		values = append(values, resource.String())
+	}
+	f.field(tag, values...)
+}
+
+func (f *fingerprinter) conditions(functions condition.Functions) {
+	values := make([]string, 0, len(functions))
+	for _, d := range functions.Describe() {
+		var g fingerprinter
+		g.writeString(d.Name)
+		g.field(d.Key, d.Values...)
+		values = append(values, g.buf.String())
+	}
+	f.field("Condition", values...)
+}
+
+func (f *fingerprinter) sum() Fingerprint {
+	return xxh3.Hash128Seed(f.buf.Bytes(), fingerprintSeed).Bytes()
+}
+
+// Fingerprint - returns the fingerprint of the statement.
+func (statement Statement) Fingerprint() Fingerprint {
+	var f fingerprinter
+	f.field("Effect", string(statement.Effect))
+	f.actions("Action", statement.Actions)
+	f.actions("NotAction", statement.NotActions)
+	f.resources("Resource", statement.Resources)
+	f.resources("NotResource", statement.NotResources)
+	f.conditions(statement.Conditions)
+	return f.sum()
+}
+
+// Fingerprint - returns the fingerprint of the statement.
+func (statement BPStatement) Fingerprint() Fingerprint {
+	var f fingerprinter
+	f.field("Effect", string(statement.Effect))
+	f.field("Principal", statement.Principal.AWS.ToSlice()...)
+	f.field("NotPrincipal", statement.NotPrincipal.AWS.ToSlice()...)
+	f.actions("Action", statement.Actions)
+	f.actions("NotAction", statement.NotActions)
+	f.resources("Resource", statement.Resources)
+	f.resources("NotResource", statement.NotResources)
+	f.conditions(statement.Conditions)
+	return f.sum()
+}
+
+// policyFingerprint - returns the fingerprint of a policy of the given kind
+// from the fingerprints of its statements. Duplicate statements are counted
+// once as they are dropped when parsing.
+func policyFingerprint(kind, version string, statements []Fingerprint) Fingerprint {
+	if version == "" {
+		version = DefaultVersion
+	}
+	values := make([]string, 0, len(statements))
+	for _, fp := range statements {
+		values = append(values, string(fp[:]))
+	}
+	sort.Strings(values)
+	unique := values[:0]
+	for _, value := range values {
+		if len(unique) == 0 || value != unique[len(unique)-1] {
+			unique = append(unique, value)
+		}
+	}
+
+	var f fingerprinter
+	f.field("Kind", kind)
+	f.field("Version", version)
+	f.field("Statement", unique...)
+	return f.sum()
+}
+
+// Fingerprint - returns the fingerprint of the policy.
+func (iamp Policy) Fingerprint() Fingerprint {
+	statements := make([]Fingerprint, 0, len(iamp.Statements))
+	for _, statement := range iamp.Statements {
+		statements = append(statements, statement.Fingerprint())
+	}
+	return policyFingerprint("Policy", iamp.Version, statements)
+}
+
+// Fingerprint - returns the fingerprint of the bucket policy.
+func (policy BucketPolicy) Fingerprint() Fingerprint {
+	statements := make([]Fingerprint, 0, len(policy.Statements))
+	for _, statement := range policy.Statements {
+		statements = append(statements, statement.Fingerprint())
+	}
+	return policyFingerprint("BucketPolicy", policy.Version, statements)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPolicyFingerprint(t *testing.T) {
+	base := `{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject", "s3:PutObject"], "Resource": ["arn:aws:s3:::a/*", "arn:aws:s3:::b/*"],
+     "Condition": {"StringEquals": {"aws:username": ["alice", "bob"]}}},
+    {"Effect": "Deny", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::a/*"]}
+]}`
+
+	testCases := []struct {
+		data           string
+		expectedResult bool
+	}{
+		// reordered statements, actions, resources and values, different SID.
+		{`{"Statement": [
+    {"Sid": "NoDelete", "Effect": "Deny", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::a/*"]},
+    {"Effect": "Allow", "Action": ["s3:PutObject", "s3:GetObject"], "Resource": ["arn:aws:s3:::b/*", "arn:aws:s3:::a/*"],
+     "Condition": {"StringEquals": {"aws:username": ["bob", "alice"]}}}
+]}`, true},
+		// duplicate statement.
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject", "s3:PutObject"], "Resource": ["arn:aws:s3:::a/*", "arn:aws:s3:::b/*"],
+     "Condition": {"StringEquals": {"aws:username": ["alice", "bob"]}}},
+    {"Effect": "Deny", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::a/*"]},
+    {"Effect": "Deny", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::a/*"]}
+]}`, true},
+		// different condition value.
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject", "s3:PutObject"], "Resource": ["arn:aws:s3:::a/*", "arn:aws:s3:::b/*"],
+     "Condition": {"StringEquals": {"aws:username": ["alice"]}}},
+    {"Effect": "Deny", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::a/*"]}
+]}`, false},
+		// condition values joined by a NUL byte.
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject", "s3:PutObject"], "Resource": ["arn:aws:s3:::a/*", "arn:aws:s3:::b/*"],
+     "Condition": {"StringEquals": {"aws:username": ["alice\u0000bob"]}}},
+    {"Effect": "Deny", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::a/*"]}
+]}`, false},
+		// NotResource instead of Resource.
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject", "s3:PutObject"], "Resource": ["arn:aws:s3:::a/*", "arn:aws:s3:::b/*"],
+     "Condition": {"StringEquals": {"aws:username": ["alice", "bob"]}}},
+    {"Effect": "Deny", "Action": ["s3:DeleteObject"], "NotResource": ["arn:aws:s3:::a/*"]}
+]}`, false},
+		// resource moved to another statement.
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject", "s3:PutObject"], "Resource": ["arn:aws:s3:::b/*"],
+     "Condition": {"StringEquals": {"aws:username": ["alice", "bob"]}}},
+    {"Effect": "Deny", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::a/*", "arn:aws:s3:::a/*"]}
+]}`, false},
+	}
+
+	fp := parseTestPolicy(t, base).Fingerprint()
+	// The fingerprint must be stable across releases.
+	if expected := "7f20d12274efcb14754bd407fe7af739"; fp.String() != expected {
+		t.Fatalf("expected: %v, got: %v", expected, fp)
+	}
+
+	for i, testCase := range testCases {
+		result := parseTestPolicy(t, testCase.data).Fingerprint() == fp
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	text, err := fp.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	var got Fingerprint
+	if err = got.UnmarshalText(text); err != nil || got != fp {
+		t.Fatalf("expected: %v, got: %v, %v", fp, got, err)
+	}
+	if err = got.UnmarshalText([]byte("abc")); err == nil {
+		t.Fatalf("expected error for invalid fingerprint")
+	}
+}
+
+func TestBucketPolicyFingerprint(t *testing.T) {
+	policy1, err := ParseBucketPolicyConfig(strings.NewReader(`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Principal": {"AWS": ["alice", "bob"]}, "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::a/*"]}
+]}`), "a")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	policy2, err := ParseBucketPolicyConfig(strings.NewReader(`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Principal": {"AWS": ["bob", "alice"]}, "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::a/*"]}
+]}`), "a")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	policy3, err := ParseBucketPolicyConfig(strings.NewReader(`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Principal": {"AWS": ["alice"]}, "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::a/*"]}
+]}`), "a")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	if policy1.Fingerprint() != policy2.Fingerprint() {
+		t.Fatalf("expected equal fingerprints")
+	}
+	if policy1.Fingerprint() == policy3.Fingerprint() {
+		t.Fatalf("expected different fingerprints")
+	}
+
+	// Empty IAM and bucket policies have different fingerprints.
+	if (Policy{}).Fingerprint() == (BucketPolicy{}).Fingerprint() {
+		t.Fatalf("expected different fingerprints")
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// DefaultPrefixThreshold - default number of object names under a common
+// prefix above which they are collapsed into a wildcard pattern.
+const DefaultPrefixThreshold = 10
+
+type observedRequest struct {
+	action Action
+	bucket string
+	object string
+}
+
+// Generator - generates a least-privilege policy allowing a set of observed
+// requests, e.g. from audit logs.
+type Generator struct {
+	prefixThreshold int
+	observed        map[observedRequest]Args
+}
+
+// NewGenerator - creates new policy generator. Object names are collapsed
+// into a 'prefix/*' pattern when there are more than prefixThreshold
+// distinct names or collapsed prefixes directly under the prefix. Zero
+// means DefaultPrefixThreshold and a negative value never collapses object
+// names.
+func NewGenerator(prefixThreshold int) *Generator {
+	if prefixThreshold == 0 {
+		prefixThreshold = DefaultPrefixThreshold
+	}
+	return &Generator{
+		prefixThreshold: prefixThreshold,
+		observed:        make(map[observedRequest]Args),
+	}
+}
+
+// Add - records the given observed requests.
+func (g *Generator) Add(requests ...Args) {
+	for _, args := range requests {
+		key := observedRequest{
+			action: args.Action,
+			bucket: args.BucketName,
+			object: strings.TrimPrefix(args.ObjectName, "/"),
+		}
+		g.observed[key] = args
+	}
+}
+
+// Policy - returns the policy allowing all observed requests. Actions with
+// the same resources are grouped into one statement, admin, KMS and STS
+// actions are allowed without resources. An error is returned if a request
+// has an unsupported action.
+func (g *Generator) Policy() (Policy, error) {
+	var adminActions, kmsActions, stsActions []Action
+	buckets := make(map[Action]map[string]struct{})
+	objects := make(map[Action]map[string][]string)
+
+	for req := range g.observed {
+		switch {
+		case AdminAction(req.action).IsValid():
+			adminActions = append(adminActions, req.action)
+		case KMSAction(req.action).IsValid():
+			kmsActions = append(kmsActions, req.action)
+		case STSAction(req.action).IsValid():
+			stsActions = append(stsActions, req.action)
+		case !req.action.IsValid():
+			return Policy{}, Errorf("unsupported action '%v'", req.action)
+		case req.bucket == "":
+			// Requests without a bucket match the resource '/'.
+			if buckets[req.action] == nil {
+				buckets[req.action] = make(map[string]struct{})
+			}
+			buckets[req.action]["*"] = struct{}{}
+		case req.object == "":
+			if buckets[req.action] == nil {
+				buckets[req.action] = make(map[string]struct{})
+			}
+			buckets[req.action][req.bucket] = struct{}{}
+		default:
+			if objects[req.action] == nil {
+				objects[req.action] = make(map[string][]string)
+			}
+			objects[req.action][req.bucket] = append(objects[req.action][req.bucket], req.object)
+		}
+	}
+
+	// Group actions by their resources.
+	groups := make(map[string]ActionSet)
+	resourcesByKey := make(map[string][]string)
+	addGroup := func(action Action, resources []string) {
+		sort.Strings(resources)
+		key := strings.Join(resources, "\x00")
+		if groups[key] == nil {
+			groups[key] = NewActionSet()
+			resourcesByKey[key] = resources
+		}
+		groups[key].Add(action)
+	}
+
+	s3Actions := make(map[Action]struct{})
+	for action := range buckets {
+		s3Actions[action] = struct{}{}
+	}
+	for action := range objects {
+		s3Actions[action] = struct{}{}
+	}
+	for action := range s3Actions {
+		var resources []string
+		for bucket := range buckets[action] {
+			resources = append(resources, bucket)
+		}
+		for bucket, names := range objects[action] {
+			for _, pattern := range collapseObjects(names, g.prefixThreshold) {
+				resources = append(resources, bucket+"/"+pattern)
+			}
+		}
+		addGroup(action, resources)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	policy := Policy{Version: DefaultVersion}
+	for _, key := range keys {
+		resources := NewResourceSet()
+		for _, resource := range resourcesByKey[key] {
+			resources.Add(NewResource(resource))
+		}
+		policy.Statements = append(policy.Statements,
+			NewStatement("", Allow, groups[key], resources, condition.NewFunctions()))
+	}
+	for _, actions := range [][]Action{adminActions, kmsActions, stsActions} {
+		if len(actions) > 0 {
+			policy.Statements = append(policy.Statements,
+				NewStatement("", Allow, NewActionSet(actions...), nil, condition.NewFunctions()))
+		}
+	}
+
+	if err := policy.Validate(); err != nil {
+		return Policy{}, err
+	}
+	policy.updateActionIndex()
+
+	for _, args := range g.observed {
+		args.DenyOnly = false
+		args.IsOwner = false
+		if !policy.IsAllowed(args) {
+			return Policy{}, Errorf("generated policy does not allow action '%v' on '%v'", args.Action,
+				resourceName(args.BucketName, args.ObjectName))
+		}
+	}
+
+	return policy, nil
+}
+
+// parentPrefix - returns the prefix of the directory containing the given
+// object name or collapsed pattern, e.g. "a/" for both "a/b" and "a/b/*".
+func parentPrefix(name string) string {
+	name = strings.TrimSuffix(name, "*")
+	name = strings.TrimSuffix(name, "/")
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		return name[:i+1]
+	}
+	return ""
+}
+
+// collapseObjects - returns resource patterns matching all given object
+// names. Starting from the deepest directory, entries of a directory are
+// replaced by the 'prefix*' pattern of the directory while there are more
+// than threshold of them.
+func collapseObjects(names []string, threshold int) []string {
+	entries := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if strings.ContainsAny(name, "*?$") {
+			// Avoid wildcards and policy variables in object names.
+			name = literalPrefix(name) + "*"
+		}
+		entries[name] = struct{}{}
+	}
+
+	for threshold >= 0 {
+		if _, ok := entries["*"]; ok {
+			return []string{"*"}
+		}
+
+		groups := make(map[string]int)
+		for entry := range entries {
+			groups[parentPrefix(entry)]++
+		}
+
+		found := false
+		var prefix string
+		for p, n := range groups {
+			if n <= threshold {
+				continue
+			}
+			if !found || len(p) > len(prefix) || len(p) == len(prefix) && p < prefix {
+				prefix = p
+				found = true
+			}
+		}
+		if !found {
+			break
+		}
+
+		for entry := range entries {
+			if strings.HasPrefix(entry, prefix) {
+				delete(entries, entry)
+			}
+		}
+		entries[prefix+"*"] = struct{}{}
+	}
+
+	patterns := make([]string, 0, len(entries))
+	for entry := range entries {
+		// Drop entries matched by a collapsed pattern.
+		covered := false
+		for p := range entries {
+			if p != entry && strings.HasSuffix(p, "*") && strings.HasPrefix(entry, strings.TrimSuffix(p, "*")) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			patterns = append(patterns, entry)
+		}
+	}
+	sort.Strings(patterns)
+	return patterns
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCollapseObjects(t *testing.T) {
+	testCases := []struct {
+		names          []string
+		threshold      int
+		expectedResult []string
+	}{
+		{[]string{"a", "b", "c"}, 3, []string{"a", "b", "c"}},
+		{[]string{"a", "b", "c"}, 2, []string{"*"}},
+		{[]string{"x/a", "x/b", "x/c", "y"}, 2, []string{"x/*", "y"}},
+		{[]string{"x/1/a", "x/1/b", "x/2/a", "x/2/b", "x/3/a", "x/3/b"}, 1, []string{"x/*"}},
+		{[]string{"x/1/a", "x/1/b", "x/1/c", "x/2/a"}, 2, []string{"x/1/*", "x/2/a"}},
+		{[]string{"a", "b", "c"}, -1, []string{"a", "b", "c"}},
+		{[]string{"a*b", "a${x}", "ab"}, 5, []string{"a*"}},
+		{[]string{"dir/", "dir/a"}, 5, []string{"dir/", "dir/a"}},
+	}
+
+	for i, testCase := range testCases {
+		result := collapseObjects(testCase.names, testCase.threshold)
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestGeneratorPolicy(t *testing.T) {
+	var requests []Args
+	for i := range 20 {
+		requests = append(requests, Args{Action: GetObjectAction, BucketName: "logs", ObjectName: fmt.Sprintf("2026/10/%02d.log", i)})
+	}
+	requests = append(requests,
+		Args{Action: PutObjectAction, BucketName: "logs", ObjectName: "2026/10/today.log"},
+		Args{Action: ListBucketAction, BucketName: "logs", ConditionValues: map[string][]string{"prefix": {"2026/"}}},
+		Args{Action: GetBucketLocationAction, BucketName: "logs"},
+		Args{Action: GetObjectAction, BucketName: "config", ObjectName: "/app.yaml"},
+		Args{Action: ListAllMyBucketsAction},
+		Args{Action: ServerInfoAdminAction},
+		Args{Action: KMSKeyStatusAction},
+	)
+
+	generator := NewGenerator(0)
+	generator.Add(requests...)
+	policy, err := generator.Policy()
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	expectedResult := parseTestPolicy(t, `{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:ListAllMyBuckets"], "Resource": ["arn:aws:s3:::*"]},
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::config/app.yaml", "arn:aws:s3:::logs/2026/10/*"]},
+    {"Effect": "Allow", "Action": ["s3:GetBucketLocation", "s3:ListBucket"], "Resource": ["arn:aws:s3:::logs"]},
+    {"Effect": "Allow", "Action": ["s3:PutObject"], "Resource": ["arn:aws:s3:::logs/2026/10/today.log"]},
+    {"Effect": "Allow", "Action": ["admin:ServerInfo"]},
+    {"Effect": "Allow", "Action": ["kms:KeyStatus"]}
+]}`)
+	if !expectedResult.Equals(policy) {
+		t.Fatalf("expected: %v, got: %v", expectedResult, policy)
+	}
+
+	for i, args := range requests {
+		if !policy.IsAllowed(args) {
+			t.Fatalf("case %v: expected request to be allowed", i+1)
+		}
+	}
+
+	if policy.IsAllowed(Args{Action: DeleteObjectAction, BucketName: "logs", ObjectName: "2026/10/01.log"}) {
+		t.Fatalf("expected unobserved action to be denied")
+	}
+
+	generator.Add(Args{Action: "s3:Unknown", BucketName: "logs"})
+	if _, err = generator.Policy(); err == nil {
+		t.Fatalf("expected error for unsupported action")
+	}
+}
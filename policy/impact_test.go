@@ -0,0 +1,117 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+func TestAnalyzeImpact(t *testing.T) {
+	readOnly := DefaultPolicies[1].Definition
+	writeData := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement("",
+				Allow,
+				NewActionSet(PutObjectAction),
+				NewResourceSet(NewResource("data/*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+	denySecret := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement("",
+				Deny,
+				NewActionSet(GetObjectAction),
+				NewResourceSet(NewResource("secret/*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+	diagnostics := DefaultPolicies[3].Definition
+
+	testCases := []struct {
+		before         []Policy
+		after          []Policy
+		resources      []string
+		expectedResult ImpactReport
+	}{
+		{[]Policy{readOnly}, []Policy{readOnly}, []string{"data/file"}, ImpactReport{}},
+		{
+			[]Policy{readOnly},
+			[]Policy{readOnly, writeData, denySecret},
+			[]string{"data/file", "secret/file"},
+			ImpactReport{
+				Grants:      []PermissionChange{{PutObjectAction, "data/file"}},
+				Revocations: []PermissionChange{{GetObjectAction, "secret/file"}},
+			},
+		},
+		{
+			[]Policy{readOnly, writeData, denySecret},
+			[]Policy{readOnly},
+			[]string{"secret/file"},
+			ImpactReport{
+				Grants: []PermissionChange{{GetObjectAction, "secret/file"}},
+			},
+		},
+		{
+			[]Policy{readOnly},
+			[]Policy{readOnly, diagnostics},
+			nil,
+			ImpactReport{
+				Grants: []PermissionChange{
+					{BandwidthMonitorAction, ""},
+					{ConsoleLogAdminAction, ""},
+					{HealthInfoAdminAction, ""},
+					{ProfilingAdminAction, ""},
+					{PrometheusAdminAction, ""},
+					{ServerInfoAdminAction, ""},
+					{TraceAdminAction, ""},
+					{TopLocksAdminAction, ""},
+				},
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		result := AnalyzeImpact(testCase.before, testCase.after, testCase.resources, nil)
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %+v, got: %+v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestAnalyzeImpactAgreesWithIsAllowedActions(t *testing.T) {
+	for _, p := range DefaultPolicies {
+		report := AnalyzeImpact(nil, []Policy{p.Definition}, []string{"mybucket/myobject"}, nil)
+		allowed := p.Definition.IsAllowedActions("mybucket", "myobject", nil)
+		if len(report.Revocations) != 0 || len(report.Grants) != len(allowed) {
+			t.Fatalf("%v: expected: %v grants, got: %v", p.Name, len(allowed), len(report.Grants))
+		}
+		for _, grant := range report.Grants {
+			if !allowed.Contains(grant.Action) {
+				t.Fatalf("%v: unexpected grant %v", p.Name, grant.Action)
+			}
+		}
+	}
+}
@@ -0,0 +1,547 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// ImportAction - how an element of an imported policy was handled.
+type ImportAction string
+
+// Possible import actions.
+const (
+	// ImportTranslated - element was rewritten to its MinIO equivalent.
+	ImportTranslated ImportAction = "translated"
+	// ImportDropped - element is not supported and was discarded.
+	ImportDropped ImportAction = "dropped"
+)
+
+// ImportChange - a change made to a policy by ImportAWSPolicy().
+type ImportChange struct {
+	Action ImportAction `json:"action"`
+	// Statement is the index of the statement in the imported policy, or -1
+	// for policy level elements.
+	Statement int    `json:"statement"`
+	Field     string `json:"field"`
+	Value     string `json:"value,omitempty"`
+	// Replacement is the value of a translated element.
+	Replacement string `json:"replacement,omitempty"`
+	Reason      string `json:"reason"`
+}
+
+func (c ImportChange) String() string {
+	location := "policy"
+	if c.Statement >= 0 {
+		location = fmt.Sprintf("statement %v", c.Statement)
+	}
+	if c.Action == ImportTranslated {
+		return fmt.Sprintf("%v: %v %v '%v' to '%v' (%v)", location, c.Action, c.Field, c.Value, c.Replacement, c.Reason)
+	}
+	if c.Value == "" {
+		return fmt.Sprintf("%v: %v %v (%v)", location, c.Action, c.Field, c.Reason)
+	}
+	return fmt.Sprintf("%v: %v %v '%v' (%v)", location, c.Action, c.Field, c.Value, c.Reason)
+}
+
+// ImportReport - all changes made to a policy by ImportAWSPolicy().
+type ImportReport struct {
+	Changes []ImportChange `json:"changes"`
+}
+
+// Lossless - returns whether the imported policy has the same meaning as the
+// original one, i.e. nothing was dropped.
+func (r ImportReport) Lossless() bool {
+	for _, change := range r.Changes {
+		if change.Action == ImportDropped {
+			return false
+		}
+	}
+	return true
+}
+
+// importValues - string or list of strings of a policy element.
+type importValues []string
+
+func (v *importValues) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*v = importValues{s}
+		return nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*v = values
+	return nil
+}
+
+// importStatements - single statement or list of statements of a policy.
+type importStatements []map[string]json.RawMessage
+
+func (s *importStatements) UnmarshalJSON(data []byte) error {
+	var statement map[string]json.RawMessage
+	if err := json.Unmarshal(data, &statement); err == nil {
+		*s = importStatements{statement}
+		return nil
+	}
+
+	var statements []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &statements); err != nil {
+		return err
+	}
+	*s = statements
+	return nil
+}
+
+// awsPartitions - ARN partitions of AWS, which are all mapped to "aws".
+var awsPartitions = []string{"aws", "aws-cn", "aws-us-gov", "aws-iso", "aws-iso-b"}
+
+// importResource - returns the MinIO resource of an AWS resource ARN, or an
+// error describing why it is not supported.
+func importResource(s string) (Resource, error) {
+	if s == "*" || strings.HasPrefix(s, ResourceARNKMSPrefix) {
+		r, err := ParseResource(s)
+		if err != nil {
+			return r, err
+		}
+		return r, r.Validate()
+	}
+
+	// arn:partition:service:region:account:resource
+	tokens := strings.SplitN(s, ":", 6)
+	if len(tokens) != 6 || tokens[0] != "arn" {
+		return Resource{}, Errorf("not an ARN")
+	}
+	partition, service, region, account, resource := tokens[1], tokens[2], tokens[3], tokens[4], tokens[5]
+
+	known := false
+	for _, p := range awsPartitions {
+		known = known || p == partition
+	}
+	switch {
+	case !known:
+		return Resource{}, Errorf("unknown partition '%v'", partition)
+	case service == "s3" && (region != "" || account != ""):
+		return Resource{}, Errorf("access points and other account resources are not supported")
+	case service != "s3" && service != "s3tables":
+		return Resource{}, Errorf("service '%v' is not supported", service)
+	}
+
+	// S3 Tables resources are not bound to a region or an account.
+	r, err := ParseResource(fmt.Sprintf("arn:aws:%v:::%v", service, resource))
+	if err != nil {
+		return r, err
+	}
+	if err = r.Validate(); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// minioServices - services of the actions and resource ARNs MinIO evaluates.
+var minioServices = map[string]bool{
+	"s3":        true,
+	"s3express": true,
+	"s3tables":  true,
+	"admin":     true,
+	"kms":       true,
+	"sts":       true,
+}
+
+// foreignService - returns whether the service is one MinIO does not
+// evaluate, so that its actions and resources never match a MinIO request.
+func foreignService(service string) bool {
+	return service != "" && !strings.ContainsAny(service, "*?") && !minioServices[strings.ToLower(service)]
+}
+
+// foreignAction - returns whether the action belongs to another service.
+func foreignAction(action string) bool {
+	service, _, found := strings.Cut(action, ":")
+	return found && foreignService(service)
+}
+
+// foreignResource - returns whether the resource is an AWS ARN of another
+// service.
+func foreignResource(s string) bool {
+	tokens := strings.SplitN(s, ":", 6)
+	if len(tokens) != 6 || tokens[0] != "arn" {
+		return false
+	}
+	for _, p := range awsPartitions {
+		if p == tokens[1] {
+			return foreignService(tokens[2])
+		}
+	}
+	return false
+}
+
+// importer - collects the changes made while importing a policy.
+type importer struct {
+	report ImportReport
+}
+
+func (im *importer) translated(statement int, field, value, replacement, reason string) {
+	im.report.Changes = append(im.report.Changes, ImportChange{
+		Action:      ImportTranslated,
+		Statement:   statement,
+		Field:       field,
+		Value:       value,
+		Replacement: replacement,
+		Reason:      reason,
+	})
+}
+
+func (im *importer) dropped(statement int, field, value, reason string) {
+	im.report.Changes = append(im.report.Changes, ImportChange{
+		Action:    ImportDropped,
+		Statement: statement,
+		Field:     field,
+		Value:     value,
+		Reason:    reason,
+	})
+}
+
+// importAction - returns whether the action is a supported S3, admin, KMS
+// or STS action.
+func importAction(action Action) bool {
+	return action.IsValid() || AdminAction(action).IsValid() || KMSAction(action).IsValid() || STSAction(action).IsValid()
+}
+
+// actions - returns the supported actions of the statement element and the
+// values dropped from it which could match a MinIO request.
+func (im *importer) actions(index int, field string, data json.RawMessage) (ActionSet, []string, error) {
+	var values importValues
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, nil, err
+	}
+
+	actions := NewActionSet()
+	var dropped []string
+	for _, value := range values {
+		if action := Action(value); importAction(action) {
+			actions.Add(action)
+			continue
+		}
+		im.dropped(index, field, value, "unsupported action")
+		if !foreignAction(value) {
+			dropped = append(dropped, value)
+		}
+	}
+	return actions, dropped, nil
+}
+
+// resources - returns the supported resources of the statement element and
+// the values dropped from it which could match a MinIO request.
+func (im *importer) resources(index int, field string, data json.RawMessage) (ResourceSet, []string, error) {
+	var values importValues
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, nil, err
+	}
+
+	resources := NewResourceSet()
+	var dropped []string
+	for _, value := range values {
+		resource, err := importResource(value)
+		if err != nil {
+			im.dropped(index, field, value, err.Error())
+			if !foreignResource(value) {
+				dropped = append(dropped, value)
+			}
+			continue
+		}
+		if value != "*" && resource.String() != value {
+			im.translated(index, field, value, resource.String(), "resource ARNs have no partition, region or account")
+		}
+		resources.Add(resource)
+	}
+	return resources, dropped, nil
+}
+
+// narrowed - returns whether dropping values of the field makes the
+// statement allow more: an Allow statement then matches more requests
+// through NotAction or NotResource, a Deny statement matches fewer requests
+// through Action or Resource. Values of other services never match a MinIO
+// request, so dropping them changes neither.
+func narrowed(effect Effect, field string) bool {
+	if effect == Allow {
+		return field == "NotAction" || field == "NotResource"
+	}
+	return field == "Action" || field == "Resource"
+}
+
+// importConditions - returns the supported conditions of the statement
+// element and the operator and key of each condition left out.
+func importConditions(statement Statement, data json.RawMessage) (functions condition.Functions, unsupported []string, err error) {
+	var operators map[string]map[string]json.RawMessage
+	if err = json.Unmarshal(data, &operators); err != nil {
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(operators))
+	for name := range operators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		keys := make([]string, 0, len(operators[name]))
+		for key := range operators[name] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			single, err := json.Marshal(map[string]map[string]json.RawMessage{name: {key: operators[name][key]}})
+			if err != nil {
+				return nil, nil, err
+			}
+
+			var parsed condition.Functions
+			if err = json.Unmarshal(single, &parsed); err == nil {
+				err = parsed.ValidateVariables()
+			}
+			if err == nil {
+				for action := range statement.Actions {
+					if diff := parsed.Keys().Difference(IAMActionConditionKeyMap.Lookup(action)); !diff.IsEmpty() {
+						err = Errorf("condition key is not supported for action '%v'", action)
+						break
+					}
+				}
+			}
+			if err != nil {
+				unsupported = append(unsupported, name+":"+key)
+				continue
+			}
+			functions = append(functions, parsed...)
+		}
+	}
+	return functions, unsupported, nil
+}
+
+// statement - returns the MinIO statement of an AWS statement, ok is false
+// if it was dropped as a whole.
+func (im *importer) statement(index int, fields map[string]json.RawMessage) (statement Statement, ok bool, err error) {
+	// The effect decides which values can be dropped.
+	if data, found := fields["Effect"]; found {
+		if err = json.Unmarshal(data, &statement.Effect); err != nil {
+			return statement, false, err
+		}
+	}
+	if !statement.Effect.IsValid() {
+		im.dropped(index, "Statement", "", fmt.Sprintf("invalid Effect '%v'", statement.Effect))
+		return statement, false, nil
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		data := fields[name]
+		var dropped []string
+		switch name {
+		case "Sid":
+			var sid ID
+			if err = json.Unmarshal(data, &sid); err != nil {
+				return statement, false, err
+			}
+			if sid.IsValid() {
+				statement.SID = sid
+			} else {
+				im.dropped(index, name, string(sid), "invalid statement ID")
+			}
+		case "Effect":
+			// Parsed above.
+		case "Action":
+			if statement.Actions, dropped, err = im.actions(index, name, data); err != nil {
+				return statement, false, err
+			}
+		case "NotAction":
+			if statement.NotActions, dropped, err = im.actions(index, name, data); err != nil {
+				return statement, false, err
+			}
+		case "Resource":
+			if statement.Resources, dropped, err = im.resources(index, name, data); err != nil {
+				return statement, false, err
+			}
+		case "NotResource":
+			if statement.NotResources, dropped, err = im.resources(index, name, data); err != nil {
+				return statement, false, err
+			}
+		case "Condition":
+		case "Principal", "NotPrincipal":
+			im.dropped(index, name, string(data), "identity policies apply to the principals they are attached to")
+		default:
+			im.dropped(index, name, "", "unknown statement field")
+		}
+
+		if len(dropped) > 0 && narrowed(statement.Effect, name) {
+			if statement.Effect == Deny {
+				return statement, false, Errorf("dropping %v '%v' would deny less", name, dropped[0])
+			}
+			im.dropped(index, "Statement", "", fmt.Sprintf("dropping %v would allow more", name))
+			return statement, false, nil
+		}
+	}
+
+	// An empty NotAction or NotResource would match everything. Allow
+	// statements are dropped, Deny statements keep denying all S3 resources
+	// but cannot deny the actions of all services in one statement.
+	if _, found := fields["NotAction"]; found && len(statement.NotActions) == 0 {
+		if statement.Effect == Deny {
+			return statement, false, Errorf("no supported NotAction, all actions cannot be denied")
+		}
+		im.dropped(index, "Statement", "", "no supported NotAction")
+		return statement, false, nil
+	}
+	if _, found := fields["NotResource"]; found && len(statement.NotResources) == 0 {
+		if statement.Effect == Allow {
+			im.dropped(index, "Statement", "", "no supported NotResource")
+			return statement, false, nil
+		}
+		statement.Resources = NewResourceSet(NewResource("*"))
+		im.translated(index, "NotResource", "", ResourceARNPrefix+"*", "no supported NotResource")
+	}
+	// A Deny statement left without actions or resources only named other
+	// services and never applies, one which had none fails validation below.
+	_, hasAction := fields["Action"]
+	if len(statement.Actions) == 0 && len(statement.NotActions) == 0 && (hasAction || statement.Effect == Allow) {
+		im.dropped(index, "Statement", "", "no supported Action")
+		return statement, false, nil
+	}
+	_, hasResource := fields["Resource"]
+	_, hasNotResource := fields["NotResource"]
+	// Admin, KMS and STS statements may apply to all resources.
+	serviceOnly := statement.isAdmin() || statement.isKMS() || statement.isSTS()
+	noResource := len(statement.Resources) == 0 && len(statement.NotResources) == 0
+	if statement.Effect == Allow && noResource && (hasResource || hasNotResource || !serviceOnly) ||
+		statement.Effect == Deny && noResource && hasResource && !serviceOnly {
+		im.dropped(index, "Statement", "", "no supported Resource")
+		return statement, false, nil
+	}
+
+	if data, found := fields["Condition"]; found {
+		functions, unsupported, err := importConditions(statement, data)
+		if err != nil {
+			return statement, false, err
+		}
+		if len(unsupported) > 0 && statement.Effect == Allow {
+			// Dropping a condition would allow more than intended.
+			im.dropped(index, "Statement", "", fmt.Sprintf("unsupported conditions %v", unsupported))
+			return statement, false, nil
+		}
+		for _, c := range unsupported {
+			im.dropped(index, "Condition", c, "unsupported condition")
+		}
+		statement.Conditions = functions
+	}
+
+	if err = statement.isValid(); err != nil {
+		if statement.Effect == Deny {
+			return statement, false, err
+		}
+		im.dropped(index, "Statement", "", err.Error())
+		return statement, false, nil
+	}
+	return statement, true, nil
+}
+
+// ImportAWSPolicy - parses an AWS IAM identity policy leniently. Unlike
+// ParseConfig(), unsupported actions, resources, condition keys and other
+// elements are dropped or translated to their MinIO equivalent instead of
+// failing, and the returned report lists each change for review. Elements
+// are only dropped if the policy allows no more than before: an Allow
+// statement with an unsupported condition, NotAction or NotResource is
+// dropped as a whole, whereas only the condition is dropped from a Deny
+// statement. Actions and resources of services other than S3, admin, KMS and
+// STS are dropped from Deny statements, as are Deny statements naming only
+// such services. An error, with an empty report, is returned if the policy
+// is not well-formed JSON or if a Deny statement would deny less, i.e. it
+// has an unsupported S3 action or resource or is invalid.
+func ImportAWSPolicy(reader io.Reader) (*Policy, ImportReport, error) {
+	var im importer
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, ImportReport{}, Errorf("%w", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, ImportReport{}, Errorf("%w", err)
+	}
+
+	iamp := Policy{Version: DefaultVersion}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var statements importStatements
+	for _, name := range names {
+		switch name {
+		case "Version":
+			var version string
+			if err = json.Unmarshal(fields[name], &version); err != nil {
+				return nil, ImportReport{}, Errorf("invalid Version: %w", err)
+			}
+			if version != DefaultVersion {
+				im.translated(-1, name, version, DefaultVersion, "only the current policy language version is supported")
+			}
+		case "Id":
+			if err = json.Unmarshal(fields[name], &iamp.ID); err != nil {
+				return nil, ImportReport{}, Errorf("invalid Id: %w", err)
+			}
+		case "Statement":
+			if err = json.Unmarshal(fields[name], &statements); err != nil {
+				return nil, ImportReport{}, Errorf("invalid Statement: %w", err)
+			}
+		default:
+			im.dropped(-1, name, "", "unknown policy field")
+		}
+	}
+	if _, found := fields["Version"]; !found {
+		im.translated(-1, "Version", "", DefaultVersion, "policy language version is required")
+	}
+
+	for i, fields := range statements {
+		statement, ok, err := im.statement(i, fields)
+		if err != nil {
+			return nil, ImportReport{}, Errorf("statement %v: %w", i, err)
+		}
+		if ok {
+			iamp.Statements = append(iamp.Statements, statement)
+		}
+	}
+
+	iamp.dropDuplicateStatements()
+	iamp.updateActionIndex()
+	return &iamp, im.report, nil
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImportAWSPolicy(t *testing.T) {
+	testCases := []struct {
+		data             string
+		expectedPolicy   string
+		expectedChanges  []string
+		expectedLossless bool
+	}{
+		// Supported policies are imported unchanged.
+		{
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`,
+			`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`,
+			nil,
+			true,
+		},
+		// Unsupported actions and resources are dropped, partitions are
+		// translated, a single statement object is accepted.
+		{
+			`{"Statement": {"Sid": "ReadOnly", "Effect": "Allow",
+			  "Action": ["s3:GetObject", "s3:GetAccessPoint", "iam:*"],
+			  "Resource": ["arn:aws-cn:s3:::mybucket/*", "arn:aws:s3:us-east-1:123456789012:accesspoint/ap", "arn:aws:iam::123456789012:role/r"]}}`,
+			`{"Version": "2012-10-17", "Statement": [{"Sid": "ReadOnly", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*"}]}`,
+			[]string{
+				"policy: translated Version '' to '2012-10-17' (policy language version is required)",
+				"statement 0: dropped Action 's3:GetAccessPoint' (unsupported action)",
+				"statement 0: dropped Action 'iam:*' (unsupported action)",
+				"statement 0: translated Resource 'arn:aws-cn:s3:::mybucket/*' to 'arn:aws:s3:::mybucket/*' (resource ARNs have no partition, region or account)",
+				"statement 0: dropped Resource 'arn:aws:s3:us-east-1:123456789012:accesspoint/ap' (access points and other account resources are not supported)",
+				"statement 0: dropped Resource 'arn:aws:iam::123456789012:role/r' (service 'iam' is not supported)",
+			},
+			false,
+		},
+		// Allow statements with unsupported conditions are dropped, Deny
+		// statements only lose the condition.
+		{
+			`{"Version": "2008-10-17", "Statement": [
+			  {"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*",
+			   "Condition": {"StringEquals": {"aws:RequestedRegion": "us-east-1"}}},
+			  {"Effect": "Deny", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/*",
+			   "Condition": {"Bool": {"aws:MultiFactorAuthPresent": "false"}, "StringEquals": {"s3:x-amz-server-side-encryption": "AES256"}}},
+			  {"Effect": "Allow", "Action": "s3:ListBucket", "Resource": "arn:aws:s3:::mybucket",
+			   "Condition": {"StringLike": {"s3:prefix": "home/*"}}}
+			]}`,
+			`{"Version": "2012-10-17", "Statement": [
+			  {"Effect": "Deny", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/*",
+			   "Condition": {"StringEquals": {"s3:x-amz-server-side-encryption": "AES256"}}},
+			  {"Effect": "Allow", "Action": "s3:ListBucket", "Resource": "arn:aws:s3:::mybucket",
+			   "Condition": {"StringLike": {"s3:prefix": "home/*"}}}
+			]}`,
+			[]string{
+				"policy: translated Version '2008-10-17' to '2012-10-17' (only the current policy language version is supported)",
+				"statement 0: dropped Statement (unsupported conditions [StringEquals:aws:RequestedRegion])",
+				"statement 1: dropped Condition 'Bool:aws:MultiFactorAuthPresent' (unsupported condition)",
+			},
+			false,
+		},
+		// Principals, unknown fields and statements left without actions or
+		// resources are dropped.
+		{
+			`{"Version": "2012-10-17", "Id": "p1", "Extra": true, "Statement": [
+			  {"Effect": "Allow", "Principal": "*", "Action": "s3:*", "Resource": "*", "Other": 1},
+			  {"Effect": "Allow", "Action": "ec2:*", "Resource": "*"},
+			  {"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/${aws:unknown}"},
+			  {"Effect": "Allow", "NotAction": "iam:*", "Resource": "*"},
+			  {"Effect": "Deny", "NotAction": ["iam:*", "s3:GetObject"], "NotResource": "arn:aws:s3:us-east-1:123456789012:accesspoint/ap"}
+			]}`,
+			`{"Version": "2012-10-17", "ID": "p1", "Statement": [
+			  {"Effect": "Allow", "Action": "s3:*", "Resource": "*"},
+			  {"Effect": "Deny", "NotAction": "s3:GetObject", "Resource": "arn:aws:s3:::*"}
+			]}`,
+			[]string{
+				"policy: dropped Extra (unknown policy field)",
+				"statement 0: dropped Other (unknown statement field)",
+				"statement 0: dropped Principal '\"*\"' (identity policies apply to the principals they are attached to)",
+				"statement 1: dropped Action 'ec2:*' (unsupported action)",
+				"statement 1: dropped Statement (no supported Action)",
+				"statement 2: dropped Resource 'arn:aws:s3:::bucket/${aws:unknown}' (unknown policy variable '${aws:unknown}' in resource arn:aws:s3:::bucket/${aws:unknown})",
+				"statement 2: dropped Statement (no supported Resource)",
+				"statement 3: dropped NotAction 'iam:*' (unsupported action)",
+				"statement 3: dropped Statement (no supported NotAction)",
+				"statement 4: dropped NotAction 'iam:*' (unsupported action)",
+				"statement 4: dropped NotResource 'arn:aws:s3:us-east-1:123456789012:accesspoint/ap' (access points and other account resources are not supported)",
+				"statement 4: translated NotResource '' to 'arn:aws:s3:::*' (no supported NotResource)",
+			},
+			false,
+		},
+		// Allow statements losing a NotResource are dropped, admin, KMS and
+		// STS actions are supported.
+		{
+			`{"Version": "2012-10-17", "Statement": [
+			  {"Effect": "Allow", "Action": "s3:GetObject", "NotResource": ["arn:aws:s3:::mybucket/private/*", "arn:aws:s3:us-east-1:123456789012:accesspoint/ap"]},
+			  {"Effect": "Allow", "Action": ["admin:ServerInfo", "admin:ConfigUpdate"]},
+			  {"Effect": "Deny", "Action": "kms:*", "Resource": "arn:minio:kms:::secret-*"},
+			  {"Effect": "Allow", "Action": "sts:AssumeRole", "Resource": "*"}
+			]}`,
+			`{"Version": "2012-10-17", "Statement": [
+			  {"Effect": "Allow", "Action": ["admin:ServerInfo", "admin:ConfigUpdate"]},
+			  {"Effect": "Deny", "Action": "kms:*", "Resource": "arn:minio:kms:::secret-*"},
+			  {"Effect": "Allow", "Action": "sts:AssumeRole", "Resource": "*"}
+			]}`,
+			[]string{
+				"statement 0: dropped NotResource 'arn:aws:s3:us-east-1:123456789012:accesspoint/ap' (access points and other account resources are not supported)",
+				"statement 0: dropped Statement (dropping NotResource would allow more)",
+			},
+			false,
+		},
+		// Actions and resources of other services never match a MinIO
+		// request, they are dropped from Deny statements and NotAction.
+		{
+			`{"Version": "2012-10-17", "Statement": [
+			  {"Effect": "Deny", "Action": ["s3:DeleteObject", "iam:*"], "Resource": ["arn:aws:s3:::mybucket/*", "arn:aws:sqs:us-east-1:123456789012:queue"]},
+			  {"Effect": "Deny", "Action": "ec2:*", "Resource": "*"},
+			  {"Effect": "Deny", "Action": "s3:*", "Resource": "arn:aws-cn:sns:*:*:topic"},
+			  {"Effect": "Allow", "NotAction": ["s3:PutObject", "iam:*"], "Resource": "arn:aws:s3:::mybucket/*"}
+			]}`,
+			`{"Version": "2012-10-17", "Statement": [
+			  {"Effect": "Deny", "Action": "s3:DeleteObject", "Resource": "arn:aws:s3:::mybucket/*"},
+			  {"Effect": "Allow", "NotAction": "s3:PutObject", "Resource": "arn:aws:s3:::mybucket/*"}
+			]}`,
+			[]string{
+				"statement 0: dropped Action 'iam:*' (unsupported action)",
+				"statement 0: dropped Resource 'arn:aws:sqs:us-east-1:123456789012:queue' (service 'sqs' is not supported)",
+				"statement 1: dropped Action 'ec2:*' (unsupported action)",
+				"statement 1: dropped Statement (no supported Action)",
+				"statement 2: dropped Resource 'arn:aws-cn:sns:*:*:topic' (service 'sns' is not supported)",
+				"statement 2: dropped Statement (no supported Resource)",
+				"statement 3: dropped NotAction 'iam:*' (unsupported action)",
+			},
+			false,
+		},
+	}
+
+	for i, testCase := range testCases {
+		policy, report, err := ImportAWSPolicy(strings.NewReader(testCase.data))
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if err = policy.Validate(); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+
+		expectedPolicy := parseTestPolicy(t, testCase.expectedPolicy)
+		if !policy.Equals(*expectedPolicy) || policy.ID != expectedPolicy.ID {
+			data, _ := json.Marshal(policy)
+			t.Fatalf("case %v: policy: expected: %v, got: %s", i+1, testCase.expectedPolicy, data)
+		}
+
+		var changes []string
+		for _, change := range report.Changes {
+			changes = append(changes, change.String())
+		}
+		if !reflect.DeepEqual(changes, testCase.expectedChanges) {
+			t.Fatalf("case %v: changes: expected: %q, got: %q", i+1, testCase.expectedChanges, changes)
+		}
+
+		if report.Lossless() != testCase.expectedLossless {
+			t.Fatalf("case %v: lossless: expected: %v, got: %v", i+1, testCase.expectedLossless, report.Lossless())
+		}
+	}
+}
+
+func TestImportAWSPolicyError(t *testing.T) {
+	testCases := []string{
+		`{"Version": "2012-10-17", "Statement": [`,
+		`{"Version": 1, "Statement": []}`,
+		`{"Version": "2012-10-17", "Statement": "s3:*"}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": 1, "Resource": "*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:*", "Resource": "*", "Condition": []}]}`,
+		// Deny statements which would deny less.
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Action": ["s3:DeleteObject", "s3:GetAccessPoint"], "Resource": "*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Action": ["s3:DeleteObject", "S3:PutObject"], "Resource": "*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Action": "s3:*", "Resource": ["arn:aws:s3:::a/*", "mybucket"]}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Action": "s3:*", "Resource": ["arn:aws:s3:::a/*", "arn:aws:s3:us-east-1:123456789012:accesspoint/ap"]}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Action": "s3:*", "Resource": ["arn:aws:sqs:us-east-1:123456789012:queue", "arn:other:sqs:::queue"]}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Action": "s3:*", "Resource": ["arn:aws:s3:::a/*", "arn:aws:s3:::b/${aws:unknown}"]}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "NotAction": "iam:*", "Resource": "*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Action": ["s3:GetObject", "admin:ServerInfo"], "Resource": "*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Action": "s3:GetObject"}]}`,
+	}
+
+	for i, testCase := range testCases {
+		policy, report, err := ImportAWSPolicy(strings.NewReader(testCase))
+		if err == nil {
+			t.Fatalf("case %v: expected error", i+1)
+		}
+		if policy != nil || len(report.Changes) != 0 {
+			t.Fatalf("case %v: expected: no policy and no changes, got: %v, %v", i+1, policy, report.Changes)
+		}
+	}
+}
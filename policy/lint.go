@@ -0,0 +1,301 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/minio/pkg/v3/wildcard"
+)
+
+// Severity - severity of a lint warning.
+type Severity uint8
+
+// Possible lint warning severities.
+const (
+	SeverityLow Severity = iota + 1
+	SeverityMedium
+	SeverityHigh
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	}
+	return "unknown"
+}
+
+// MarshalText - encodes Severity to its string representation.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// LintCheck - identifies the check which raised a lint warning.
+type LintCheck string
+
+// Supported lint checks.
+const (
+	// LintDeadStatement - statement can never match any request.
+	LintDeadStatement LintCheck = "dead-statement"
+	// LintShadowedAllow - Allow statement is fully covered by a Deny
+	// statement without conditions.
+	LintShadowedAllow LintCheck = "shadowed-allow"
+	// LintDuplicateStatement - statement is identical to another statement,
+	// ignoring SID and the order of its elements.
+	LintDuplicateStatement LintCheck = "duplicate-statement"
+	// LintRedundantStatement - statement is covered by another statement
+	// with the same effect and conditions.
+	LintRedundantStatement LintCheck = "redundant-statement"
+	// LintBroadAccess - statement allows all S3 actions on all resources.
+	LintBroadAccess LintCheck = "broad-access"
+	// LintInapplicableConditionKey - condition key is not provided for some
+	// of the statement's actions.
+	LintInapplicableConditionKey LintCheck = "inapplicable-condition-key"
+	// LintAllowNotAction - Allow statement uses NotAction, which allows
+	// every other action, including ones added in the future.
+	LintAllowNotAction LintCheck = "allow-not-action"
+)
+
+// LintWarning - a problem found in a policy statement.
+type LintWarning struct {
+	Severity Severity  `json:"severity"`
+	Check    LintCheck `json:"check"`
+	// Statement is the index of the offending statement.
+	Statement int    `json:"statement"`
+	Message   string `json:"message"`
+}
+
+func (w LintWarning) String() string {
+	return fmt.Sprintf("%v: statement %v: %v (%v)", w.Severity, w.Statement, w.Message, w.Check)
+}
+
+// Lint - checks the policy for statements which are valid but most likely
+// do not do what was intended and returns the warnings ordered by statement
+// index. Unlike Validate(), it does not fail on any of them.
+func (iamp Policy) Lint() []LintWarning {
+	var warnings []LintWarning
+	warn := func(severity Severity, check LintCheck, index int, format string, args ...any) {
+		warnings = append(warnings, LintWarning{
+			Severity:  severity,
+			Check:     check,
+			Statement: index,
+			Message:   fmt.Sprintf(format, args...),
+		})
+	}
+
+	for i, statement := range iamp.Statements {
+		if statement.Effect == Allow && len(statement.NotActions) > 0 {
+			warn(SeverityMedium, LintAllowNotAction, i, "Allow with NotAction %v allows all other actions", statement.NotActions)
+		}
+
+		if statement.Effect == Allow && statement.isBroad() {
+			warn(SeverityHigh, LintBroadAccess, i, "allows all S3 actions on all resources")
+		}
+
+		if statement.isDead() {
+			warn(SeverityHigh, LintDeadStatement, i, "object actions %v never match bucket resources %v", statement.Actions, statement.Resources)
+		}
+
+		for _, key := range statement.inapplicableConditionKeys() {
+			warn(SeverityMedium, LintInapplicableConditionKey, i, "condition key '%v' is not applicable to actions %v", key.name, key.actions)
+		}
+
+		for j, other := range iamp.Statements {
+			if i == j {
+				continue
+			}
+
+			if statement.Effect == Allow && other.Effect == Deny &&
+				len(other.Conditions) == 0 && other.covers(statement) {
+				warn(SeverityHigh, LintShadowedAllow, i, "always denied by statement %v", j)
+				continue
+			}
+
+			if statement.Effect != other.Effect || !statement.Conditions.Equals(other.Conditions) {
+				continue
+			}
+			if statement.Equals(other) {
+				// Report only the later statement of duplicates.
+				if j < i {
+					warn(SeverityLow, LintDuplicateStatement, i, "duplicate of statement %v", j)
+				}
+				continue
+			}
+			if other.covers(statement) && (j < i || !statement.covers(other)) {
+				warn(SeverityLow, LintRedundantStatement, i, "covered by statement %v", j)
+			}
+		}
+	}
+
+	return warnings
+}
+
+// isS3 - returns whether the statement has only S3 actions.
+func (statement Statement) isS3() bool {
+	return !statement.isAdmin() && !statement.isSTS() && !statement.isKMS()
+}
+
+// expandActions - returns all supported S3 actions matched by the statement
+// actions in sorted order.
+func (statement Statement) expandActions() []Action {
+	var actions []Action
+	for action := range supportedActions {
+		if statement.Actions.Match(action) {
+			actions = append(actions, action)
+		}
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i] < actions[j] })
+	return actions
+}
+
+func (statement Statement) isBroad() bool {
+	if !statement.Actions.Contains(AllActions) && !statement.Actions.Contains(Action("*")) {
+		return false
+	}
+	for resource := range statement.Resources {
+		if resource.isS3() && resource.Pattern == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// isDead - returns whether the statement has only object actions but only
+// bucket resources, which never match an object.
+func (statement Statement) isDead() bool {
+	if !statement.isS3() || len(statement.Actions) == 0 || len(statement.Resources) == 0 {
+		return false
+	}
+
+	actions := statement.expandActions()
+	if len(actions) == 0 {
+		return false
+	}
+	for _, action := range actions {
+		if !action.IsObjectAction() {
+			return false
+		}
+	}
+	return !statement.Resources.ObjectResourceExists()
+}
+
+type inapplicableKey struct {
+	name    string
+	actions []Action
+}
+
+// inapplicableConditionKeys - returns condition keys of the statement which
+// are not provided for some of its actions, along with those actions.
+func (statement Statement) inapplicableConditionKeys() []inapplicableKey {
+	if !statement.isS3() || len(statement.Conditions) == 0 {
+		return nil
+	}
+
+	byKey := make(map[string][]Action)
+	keys := statement.Conditions.Keys()
+	for _, action := range statement.expandActions() {
+		for key := range keys.Difference(IAMActionConditionKeyMap.Lookup(action)) {
+			byKey[key.String()] = append(byKey[key.String()], action)
+		}
+	}
+
+	result := make([]inapplicableKey, 0, len(byKey))
+	for name, actions := range byKey {
+		result = append(result, inapplicableKey{name: name, actions: actions})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].name < result[j].name })
+	return result
+}
+
+// covers - returns whether every action and resource matched by the given
+// statement is also matched by this statement, ignoring conditions. It is
+// conservative, i.e. it may return false for statements that are covered.
+func (statement Statement) covers(st Statement) bool {
+	if len(statement.NotActions) > 0 || len(statement.NotResources) > 0 ||
+		len(st.NotActions) > 0 || len(st.NotResources) > 0 {
+		return false
+	}
+	if len(st.Actions) == 0 || len(st.Resources) == 0 && len(statement.Resources) > 0 {
+		return false
+	}
+
+	for action := range st.Actions {
+		found := false
+		for pattern := range statement.Actions {
+			if patternCovers(string(pattern), string(action)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for resource := range st.Resources {
+		found := false
+		for pattern := range statement.Resources {
+			if resourceCovers(pattern, resource) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func resourceCovers(pattern, resource Resource) bool {
+	if pattern.Type == ResourceARNAll {
+		return true
+	}
+	if pattern.Type != resource.Type {
+		return false
+	}
+	if strings.Contains(pattern.Pattern, "${") || strings.Contains(resource.Pattern, "${") {
+		// Policy variables are substituted at evaluation time.
+		return pattern.Pattern == resource.Pattern
+	}
+	return patternCovers(pattern.Pattern, resource.Pattern)
+}
+
+// patternCovers - returns whether every string matched by pattern q is also
+// matched by pattern p. It returns false if the patterns are too complex to
+// decide.
+func patternCovers(p, q string) bool {
+	if p == q {
+		return true
+	}
+	if !wildcard.Has(q) {
+		return wildcard.Match(p, q)
+	}
+	if prefix, ok := strings.CutSuffix(p, "*"); ok && !wildcard.Has(prefix) {
+		return strings.HasPrefix(q, prefix)
+	}
+	covered, _, err := wildcard.IsSubset(q, p)
+	return err == nil && covered
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+func TestPolicyLint(t *testing.T) {
+	_, IPNet1, err := net.ParseCIDR("192.168.1.0/24")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	func1, err := condition.NewIPAddressFunc(condition.AWSSourceIP.ToKey(), IPNet1)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	func2, err := condition.NewStringEqualsFunc("", condition.AWSUsername.ToKey(), "alice")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	func3, err := condition.NewStringLikeFunc("", condition.S3Prefix.ToKey(), "home/*")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	type warning struct {
+		check     LintCheck
+		statement int
+	}
+
+	testCases := []struct {
+		statements       []Statement
+		expectedWarnings []warning
+	}{
+		// clean policy.
+		{
+			[]Statement{
+				NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions()),
+				NewStatement("", Allow, NewActionSet(ListBucketAction), NewResourceSet(NewResource("mybucket")), condition.NewFunctions()),
+			},
+			nil,
+		},
+		// s3:* on all resources.
+		{
+			[]Statement{
+				NewStatement("", Allow, NewActionSet(AllActions), NewResourceSet(NewResource("*")), condition.NewFunctions()),
+			},
+			[]warning{{LintBroadAccess, 0}},
+		},
+		// object actions on bucket resource.
+		{
+			[]Statement{
+				NewStatement("", Allow, NewActionSet(GetObjectAction, PutObjectAction), NewResourceSet(NewResource("mybucket")), condition.NewFunctions()),
+			},
+			[]warning{{LintDeadStatement, 0}},
+		},
+		// Allow shadowed by unconditional Deny.
+		{
+			[]Statement{
+				NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/data/*")), condition.NewFunctions()),
+				NewStatement("", Deny, NewActionSet("s3:Get*"), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions()),
+			},
+			[]warning{{LintShadowedAllow, 0}},
+		},
+		// conditional Deny does not shadow.
+		{
+			[]Statement{
+				NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/data/*")), condition.NewFunctions()),
+				NewStatement("", Deny, NewActionSet("s3:Get*"), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions(func1)),
+			},
+			nil,
+		},
+		// duplicate with conditions in different order.
+		{
+			[]Statement{
+				NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions(func1, func2)),
+				NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions(func2, func1)),
+			},
+			[]warning{{LintDuplicateStatement, 1}},
+		},
+		// covered by statement with same effect and conditions.
+		{
+			[]Statement{
+				NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/data/*")), condition.NewFunctions(func2)),
+				NewStatement("", Allow, NewActionSet(GetObjectAction, PutObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions(func2)),
+			},
+			[]warning{{LintRedundantStatement, 0}},
+		},
+		// different conditions are not redundant.
+		{
+			[]Statement{
+				NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/data/*")), condition.NewFunctions(func1)),
+				NewStatement("", Allow, NewActionSet(GetObjectAction, PutObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions(func2)),
+			},
+			nil,
+		},
+		// s3:prefix is only applicable to ListBucket.
+		{
+			[]Statement{
+				NewStatement("", Allow, NewActionSet(ListBucketAction, GetObjectAction), NewResourceSet(NewResource("mybucket"), NewResource("mybucket/*")), condition.NewFunctions(func3)),
+			},
+			[]warning{{LintInapplicableConditionKey, 0}},
+		},
+		// Allow with NotAction.
+		{
+			[]Statement{
+				NewStatementWithNotAction("", Allow, NewActionSet(DeleteObjectAction), NewResourceSet(NewResource("mybucket/*")), condition.NewFunctions()),
+			},
+			[]warning{{LintAllowNotAction, 0}},
+		},
+		// covered by a pattern with a wildcard before its suffix.
+		{
+			[]Statement{
+				NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/photos/*.jpg")), condition.NewFunctions(func2)),
+				NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*.jpg")), condition.NewFunctions(func2)),
+			},
+			[]warning{{LintRedundantStatement, 0}},
+		},
+		// patterns too complex to analyze are not reported.
+		{
+			[]Statement{
+				NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*a????????????????????????????????b")), condition.NewFunctions(func2)),
+				NewStatement("", Allow, NewActionSet(GetObjectAction), NewResourceSet(NewResource("mybucket/*?????????????????????????????????b")), condition.NewFunctions(func2)),
+			},
+			nil,
+		},
+	}
+
+	for i, testCase := range testCases {
+		policy := Policy{Version: DefaultVersion, Statements: testCase.statements}
+		var result []warning
+		for _, w := range policy.Lint() {
+			result = append(result, warning{w.Check, w.Statement})
+		}
+
+		if !reflect.DeepEqual(result, testCase.expectedWarnings) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedWarnings, policy.Lint())
+		}
+	}
+
+	policy := Policy{Version: DefaultVersion, Statements: testCases[8].statements}
+	if warnings := policy.Lint(); !strings.Contains(warnings[0].Message, "s3:GetObject") || strings.Contains(warnings[0].Message, "s3:ListBucket") {
+		t.Fatalf("unexpected message %v", warnings[0].Message)
+	}
+}
@@ -0,0 +1,613 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"sort"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// Policies are encoded as MessagePack maps keyed by the same field names as
+// their JSON encoding, so that fields can be added without breaking older
+// readers; unknown fields are skipped when decoding.
+
+// encodeMsg - writes the MessagePack encoding of m to w.
+func encodeMsg(w *msgp.Writer, m msgp.Marshaler) error {
+	b, err := m.MarshalMsg(nil)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// decodeMsg - reads the next MessagePack object from r into u.
+func decodeMsg(r *msgp.Reader, u msgp.Unmarshaler) error {
+	var raw msgp.Raw
+	if err := raw.DecodeMsg(r); err != nil {
+		return err
+	}
+	_, err := u.UnmarshalMsg(raw)
+	return err
+}
+
+// readStrings - reads an array of strings from b, calling fn for each of
+// them. A nil array is reported by returning false.
+func readStrings(b []byte, fn func(s string) error) (o []byte, ok bool, err error) {
+	if msgp.IsNil(b) {
+		o, err = msgp.ReadNilBytes(b)
+		return o, false, err
+	}
+
+	sz, o, err := msgp.ReadArrayHeaderBytes(b)
+	if err != nil {
+		return b, false, err
+	}
+	for i := uint32(0); i < sz; i++ {
+		var s string
+		if s, o, err = msgp.ReadStringBytes(o); err != nil {
+			return b, false, msgp.WrapError(err, i)
+		}
+		if err = fn(s); err != nil {
+			return b, false, msgp.WrapError(err, i)
+		}
+	}
+	return o, true, nil
+}
+
+// MarshalMsg - appends the MessagePack encoding of ActionSet to b.
+// Actions are sorted so that equal sets have the same encoding.
+func (actionSet ActionSet) MarshalMsg(b []byte) ([]byte, error) {
+	if actionSet == nil {
+		return msgp.AppendNil(b), nil
+	}
+
+	actions := actionSet.ToSlice()
+	sort.Slice(actions, func(i, j int) bool { return actions[i] < actions[j] })
+	o := msgp.AppendArrayHeader(b, uint32(len(actions)))
+	for _, action := range actions {
+		o = msgp.AppendString(o, string(action))
+	}
+	return o, nil
+}
+
+// UnmarshalMsg - decodes MessagePack data to ActionSet and returns the
+// remaining bytes.
+func (actionSet *ActionSet) UnmarshalMsg(b []byte) ([]byte, error) {
+	set := make(ActionSet)
+	o, ok, err := readStrings(b, func(s string) error {
+		set.Add(Action(s))
+		return nil
+	})
+	if err != nil {
+		return b, err
+	}
+
+	if !ok {
+		set = nil
+	}
+	*actionSet = set
+	return o, nil
+}
+
+// EncodeMsg - writes ActionSet as MessagePack to w.
+func (actionSet ActionSet) EncodeMsg(w *msgp.Writer) error {
+	return encodeMsg(w, actionSet)
+}
+
+// DecodeMsg - reads ActionSet as MessagePack from r.
+func (actionSet *ActionSet) DecodeMsg(r *msgp.Reader) error {
+	return decodeMsg(r, actionSet)
+}
+
+// Msgsize - returns the maximum serialized size of ActionSet in bytes.
+func (actionSet ActionSet) Msgsize() int {
+	s := msgp.ArrayHeaderSize
+	for action := range actionSet {
+		s += msgp.StringPrefixSize + len(action)
+	}
+	return s
+}
+
+// MarshalMsg - appends the MessagePack encoding of ResourceSet to b.
+// Resources are encoded as their sorted ARN strings.
+func (resourceSet ResourceSet) MarshalMsg(b []byte) ([]byte, error) {
+	if resourceSet == nil {
+		return msgp.AppendNil(b), nil
+	}
+
+	resources := make([]string, 0, len(resourceSet))
+	for resource := range resourceSet {
+		resources = append(resources, resource.String())
+	}
+	sort.Strings(resources)
+	o := msgp.AppendArrayHeader(b, uint32(len(resources)))
+	for _, resource := range resources {
+		o = msgp.AppendString(o, resource)
+	}
+	return o, nil
+}
+
+// UnmarshalMsg - decodes MessagePack data to ResourceSet and returns the
+// remaining bytes.
+func (resourceSet *ResourceSet) UnmarshalMsg(b []byte) ([]byte, error) {
+	set := make(ResourceSet)
+	o, ok, err := readStrings(b, func(s string) error {
+		resource, err := ParseResource(s)
+		if err != nil {
+			return err
+		}
+		set.Add(resource)
+		return nil
+	})
+	if err != nil {
+		return b, err
+	}
+
+	if !ok {
+		set = nil
+	}
+	*resourceSet = set
+	return o, nil
+}
+
+// EncodeMsg - writes ResourceSet as MessagePack to w.
+func (resourceSet ResourceSet) EncodeMsg(w *msgp.Writer) error {
+	return encodeMsg(w, resourceSet)
+}
+
+// DecodeMsg - reads ResourceSet as MessagePack from r.
+func (resourceSet *ResourceSet) DecodeMsg(r *msgp.Reader) error {
+	return decodeMsg(r, resourceSet)
+}
+
+// Msgsize - returns the maximum serialized size of ResourceSet in bytes.
+func (resourceSet ResourceSet) Msgsize() int {
+	s := msgp.ArrayHeaderSize
+	for resource := range resourceSet {
+		s += msgp.StringPrefixSize + len(resource.Type.String()) + len(resource.Pattern)
+	}
+	return s
+}
+
+// MarshalMsg - appends the MessagePack encoding of Principal to b.
+// Principals are sorted so that equal sets have the same encoding.
+func (p Principal) MarshalMsg(b []byte) ([]byte, error) {
+	o := msgp.AppendMapHeader(b, 1)
+	o = msgp.AppendString(o, "AWS")
+	if p.AWS == nil {
+		return msgp.AppendNil(o), nil
+	}
+	principals := p.AWS.ToSlice()
+	o = msgp.AppendArrayHeader(o, uint32(len(principals)))
+	for _, principal := range principals {
+		o = msgp.AppendString(o, principal)
+	}
+	return o, nil
+}
+
+// UnmarshalMsg - decodes MessagePack data to Principal and returns the
+// remaining bytes.
+func (p *Principal) UnmarshalMsg(b []byte) ([]byte, error) {
+	sz, o, err := msgp.ReadMapHeaderBytes(b)
+	if err != nil {
+		return b, err
+	}
+
+	var principal Principal
+	for ; sz > 0; sz-- {
+		var field []byte
+		if field, o, err = msgp.ReadMapKeyZC(o); err != nil {
+			return b, err
+		}
+		switch msgp.UnsafeString(field) {
+		case "AWS":
+			aws := set.NewStringSet()
+			var ok bool
+			o, ok, err = readStrings(o, func(s string) error {
+				aws.Add(s)
+				return nil
+			})
+			if ok {
+				principal.AWS = aws
+			}
+		default:
+			o, err = msgp.Skip(o)
+		}
+		if err != nil {
+			return b, msgp.WrapError(err, string(field))
+		}
+	}
+
+	*p = principal
+	return o, nil
+}
+
+// EncodeMsg - writes Principal as MessagePack to w.
+func (p Principal) EncodeMsg(w *msgp.Writer) error {
+	return encodeMsg(w, p)
+}
+
+// DecodeMsg - reads Principal as MessagePack from r.
+func (p *Principal) DecodeMsg(r *msgp.Reader) error {
+	return decodeMsg(r, p)
+}
+
+// Msgsize - returns the maximum serialized size of Principal in bytes.
+func (p Principal) Msgsize() int {
+	s := msgp.MapHeaderSize + msgp.StringPrefixSize + len("AWS") + msgp.ArrayHeaderSize
+	for principal := range p.AWS {
+		s += msgp.StringPrefixSize + len(principal)
+	}
+	return s
+}
+
+// appendStatementFields - appends the fields common to Statement and
+// BPStatement as MessagePack map entries to b.
+func appendStatementFields(b []byte, sid ID, effect Effect, actions, notActions ActionSet,
+	resources, notResources ResourceSet, conditions msgp.Marshaler,
+) (o []byte, err error) {
+	o = msgp.AppendString(b, "Sid")
+	o = msgp.AppendString(o, string(sid))
+	o = msgp.AppendString(o, "Effect")
+	o = msgp.AppendString(o, string(effect))
+	o = msgp.AppendString(o, "Action")
+	if o, err = actions.MarshalMsg(o); err != nil {
+		return b, msgp.WrapError(err, "Action")
+	}
+	o = msgp.AppendString(o, "NotAction")
+	if o, err = notActions.MarshalMsg(o); err != nil {
+		return b, msgp.WrapError(err, "NotAction")
+	}
+	o = msgp.AppendString(o, "Resource")
+	if o, err = resources.MarshalMsg(o); err != nil {
+		return b, msgp.WrapError(err, "Resource")
+	}
+	o = msgp.AppendString(o, "NotResource")
+	if o, err = notResources.MarshalMsg(o); err != nil {
+		return b, msgp.WrapError(err, "NotResource")
+	}
+	o = msgp.AppendString(o, "Condition")
+	if o, err = conditions.MarshalMsg(o); err != nil {
+		return b, msgp.WrapError(err, "Condition")
+	}
+	return o, nil
+}
+
+// statementFieldsMsgsize - returns the maximum serialized size of the fields
+// written by appendStatementFields() in bytes.
+func statementFieldsMsgsize(sid ID, effect Effect, actions, notActions ActionSet,
+	resources, notResources ResourceSet, conditions msgp.Sizer,
+) int {
+	return 7*msgp.StringPrefixSize + len("SidEffectActionNotActionResourceNotResourceCondition") +
+		msgp.StringPrefixSize + len(sid) + msgp.StringPrefixSize + len(effect) +
+		actions.Msgsize() + notActions.Msgsize() + resources.Msgsize() + notResources.Msgsize() +
+		conditions.Msgsize()
+}
+
+// MarshalMsg - appends the MessagePack encoding of Statement to b.
+func (statement Statement) MarshalMsg(b []byte) ([]byte, error) {
+	o := msgp.AppendMapHeader(b, 7)
+	return appendStatementFields(o, statement.SID, statement.Effect, statement.Actions, statement.NotActions,
+		statement.Resources, statement.NotResources, statement.Conditions)
+}
+
+// UnmarshalMsg - decodes MessagePack data to Statement and returns the
+// remaining bytes.
+func (statement *Statement) UnmarshalMsg(b []byte) ([]byte, error) {
+	sz, o, err := msgp.ReadMapHeaderBytes(b)
+	if err != nil {
+		return b, err
+	}
+
+	var st Statement
+	for ; sz > 0; sz-- {
+		var field []byte
+		if field, o, err = msgp.ReadMapKeyZC(o); err != nil {
+			return b, err
+		}
+		var s string
+		switch msgp.UnsafeString(field) {
+		case "Sid":
+			s, o, err = msgp.ReadStringBytes(o)
+			st.SID = ID(s)
+		case "Effect":
+			s, o, err = msgp.ReadStringBytes(o)
+			st.Effect = Effect(s)
+		case "Action":
+			o, err = st.Actions.UnmarshalMsg(o)
+		case "NotAction":
+			o, err = st.NotActions.UnmarshalMsg(o)
+		case "Resource":
+			o, err = st.Resources.UnmarshalMsg(o)
+		case "NotResource":
+			o, err = st.NotResources.UnmarshalMsg(o)
+		case "Condition":
+			o, err = st.Conditions.UnmarshalMsg(o)
+		default:
+			o, err = msgp.Skip(o)
+		}
+		if err != nil {
+			return b, msgp.WrapError(err, string(field))
+		}
+	}
+
+	*statement = st
+	return o, nil
+}
+
+// EncodeMsg - writes Statement as MessagePack to w.
+func (statement Statement) EncodeMsg(w *msgp.Writer) error {
+	return encodeMsg(w, statement)
+}
+
+// DecodeMsg - reads Statement as MessagePack from r.
+func (statement *Statement) DecodeMsg(r *msgp.Reader) error {
+	return decodeMsg(r, statement)
+}
+
+// Msgsize - returns the maximum serialized size of Statement in bytes.
+func (statement Statement) Msgsize() int {
+	return msgp.MapHeaderSize + statementFieldsMsgsize(statement.SID, statement.Effect, statement.Actions,
+		statement.NotActions, statement.Resources, statement.NotResources, statement.Conditions)
+}
+
+// MarshalMsg - appends the MessagePack encoding of BPStatement to b.
+func (statement BPStatement) MarshalMsg(b []byte) ([]byte, error) {
+	o := msgp.AppendMapHeader(b, 9)
+	o, err := appendStatementFields(o, statement.SID, statement.Effect, statement.Actions, statement.NotActions,
+		statement.Resources, statement.NotResources, statement.Conditions)
+	if err != nil {
+		return b, err
+	}
+	o = msgp.AppendString(o, "Principal")
+	if o, err = statement.Principal.MarshalMsg(o); err != nil {
+		return b, msgp.WrapError(err, "Principal")
+	}
+	o = msgp.AppendString(o, "NotPrincipal")
+	if o, err = statement.NotPrincipal.MarshalMsg(o); err != nil {
+		return b, msgp.WrapError(err, "NotPrincipal")
+	}
+	return o, nil
+}
+
+// UnmarshalMsg - decodes MessagePack data to BPStatement and returns the
+// remaining bytes.
+func (statement *BPStatement) UnmarshalMsg(b []byte) ([]byte, error) {
+	sz, o, err := msgp.ReadMapHeaderBytes(b)
+	if err != nil {
+		return b, err
+	}
+
+	var st BPStatement
+	for ; sz > 0; sz-- {
+		var field []byte
+		if field, o, err = msgp.ReadMapKeyZC(o); err != nil {
+			return b, err
+		}
+		var s string
+		switch msgp.UnsafeString(field) {
+		case "Sid":
+			s, o, err = msgp.ReadStringBytes(o)
+			st.SID = ID(s)
+		case "Effect":
+			s, o, err = msgp.ReadStringBytes(o)
+			st.Effect = Effect(s)
+		case "Principal":
+			o, err = st.Principal.UnmarshalMsg(o)
+		case "NotPrincipal":
+			o, err = st.NotPrincipal.UnmarshalMsg(o)
+		case "Action":
+			o, err = st.Actions.UnmarshalMsg(o)
+		case "NotAction":
+			o, err = st.NotActions.UnmarshalMsg(o)
+		case "Resource":
+			o, err = st.Resources.UnmarshalMsg(o)
+		case "NotResource":
+			o, err = st.NotResources.UnmarshalMsg(o)
+		case "Condition":
+			o, err = st.Conditions.UnmarshalMsg(o)
+		default:
+			o, err = msgp.Skip(o)
+		}
+		if err != nil {
+			return b, msgp.WrapError(err, string(field))
+		}
+	}
+
+	*statement = st
+	return o, nil
+}
+
+// EncodeMsg - writes BPStatement as MessagePack to w.
+func (statement BPStatement) EncodeMsg(w *msgp.Writer) error {
+	return encodeMsg(w, statement)
+}
+
+// DecodeMsg - reads BPStatement as MessagePack from r.
+func (statement *BPStatement) DecodeMsg(r *msgp.Reader) error {
+	return decodeMsg(r, statement)
+}
+
+// Msgsize - returns the maximum serialized size of BPStatement in bytes.
+func (statement BPStatement) Msgsize() int {
+	return msgp.MapHeaderSize + statementFieldsMsgsize(statement.SID, statement.Effect, statement.Actions,
+		statement.NotActions, statement.Resources, statement.NotResources, statement.Conditions) +
+		msgp.StringPrefixSize + len("Principal") + statement.Principal.Msgsize() +
+		msgp.StringPrefixSize + len("NotPrincipal") + statement.NotPrincipal.Msgsize()
+}
+
+// readPolicyFields - reads a MessagePack map of policy fields from b, calling
+// readStatement for every element of the Statement array.
+func readPolicyFields(b []byte, id *ID, version *string, readStatement func(b []byte) ([]byte, error)) ([]byte, error) {
+	sz, o, err := msgp.ReadMapHeaderBytes(b)
+	if err != nil {
+		return b, err
+	}
+
+	for ; sz > 0; sz-- {
+		var field []byte
+		if field, o, err = msgp.ReadMapKeyZC(o); err != nil {
+			return b, err
+		}
+		var s string
+		switch msgp.UnsafeString(field) {
+		case "ID":
+			s, o, err = msgp.ReadStringBytes(o)
+			*id = ID(s)
+		case "Version":
+			*version, o, err = msgp.ReadStringBytes(o)
+		case "Statement":
+			var n uint32
+			if n, o, err = msgp.ReadArrayHeaderBytes(o); err != nil {
+				break
+			}
+			for i := uint32(0); i < n; i++ {
+				if o, err = readStatement(o); err != nil {
+					err = msgp.WrapError(err, i)
+					break
+				}
+			}
+		default:
+			o, err = msgp.Skip(o)
+		}
+		if err != nil {
+			return b, msgp.WrapError(err, string(field))
+		}
+	}
+	return o, nil
+}
+
+// MarshalMsg - appends the MessagePack encoding of Policy to b.
+func (iamp Policy) MarshalMsg(b []byte) ([]byte, error) {
+	o := msgp.AppendMapHeader(b, 3)
+	o = msgp.AppendString(o, "ID")
+	o = msgp.AppendString(o, string(iamp.ID))
+	o = msgp.AppendString(o, "Version")
+	o = msgp.AppendString(o, iamp.Version)
+	o = msgp.AppendString(o, "Statement")
+	o = msgp.AppendArrayHeader(o, uint32(len(iamp.Statements)))
+	for i, statement := range iamp.Statements {
+		var err error
+		if o, err = statement.MarshalMsg(o); err != nil {
+			return b, msgp.WrapError(err, "Statement", i)
+		}
+	}
+	return o, nil
+}
+
+// UnmarshalMsg - decodes MessagePack data to Policy and returns the remaining
+// bytes. Unlike UnmarshalJSON(), statements are kept as they are, the
+// decoded policy only gets its action index rebuilt so that it is ready to
+// be evaluated.
+func (iamp *Policy) UnmarshalMsg(b []byte) ([]byte, error) {
+	var p Policy
+	o, err := readPolicyFields(b, &p.ID, &p.Version, func(b []byte) ([]byte, error) {
+		var statement Statement
+		o, err := statement.UnmarshalMsg(b)
+		p.Statements = append(p.Statements, statement)
+		return o, err
+	})
+	if err != nil {
+		return b, err
+	}
+
+	p.updateActionIndex()
+	*iamp = p
+	return o, nil
+}
+
+// EncodeMsg - writes Policy as MessagePack to w.
+func (iamp Policy) EncodeMsg(w *msgp.Writer) error {
+	return encodeMsg(w, iamp)
+}
+
+// DecodeMsg - reads Policy as MessagePack from r.
+func (iamp *Policy) DecodeMsg(r *msgp.Reader) error {
+	return decodeMsg(r, iamp)
+}
+
+// Msgsize - returns the maximum serialized size of Policy in bytes.
+func (iamp Policy) Msgsize() int {
+	s := msgp.MapHeaderSize + 3*msgp.StringPrefixSize + len("IDVersionStatement") +
+		msgp.StringPrefixSize + len(iamp.ID) + msgp.StringPrefixSize + len(iamp.Version) +
+		msgp.ArrayHeaderSize
+	for _, statement := range iamp.Statements {
+		s += statement.Msgsize()
+	}
+	return s
+}
+
+// MarshalMsg - appends the MessagePack encoding of BucketPolicy to b.
+func (policy BucketPolicy) MarshalMsg(b []byte) ([]byte, error) {
+	o := msgp.AppendMapHeader(b, 3)
+	o = msgp.AppendString(o, "ID")
+	o = msgp.AppendString(o, string(policy.ID))
+	o = msgp.AppendString(o, "Version")
+	o = msgp.AppendString(o, policy.Version)
+	o = msgp.AppendString(o, "Statement")
+	o = msgp.AppendArrayHeader(o, uint32(len(policy.Statements)))
+	for i, statement := range policy.Statements {
+		var err error
+		if o, err = statement.MarshalMsg(o); err != nil {
+			return b, msgp.WrapError(err, "Statement", i)
+		}
+	}
+	return o, nil
+}
+
+// UnmarshalMsg - decodes MessagePack data to BucketPolicy and returns the
+// remaining bytes.
+func (policy *BucketPolicy) UnmarshalMsg(b []byte) ([]byte, error) {
+	var p BucketPolicy
+	o, err := readPolicyFields(b, &p.ID, &p.Version, func(b []byte) ([]byte, error) {
+		var statement BPStatement
+		o, err := statement.UnmarshalMsg(b)
+		p.Statements = append(p.Statements, statement)
+		return o, err
+	})
+	if err != nil {
+		return b, err
+	}
+
+	*policy = p
+	return o, nil
+}
+
+// EncodeMsg - writes BucketPolicy as MessagePack to w.
+func (policy BucketPolicy) EncodeMsg(w *msgp.Writer) error {
+	return encodeMsg(w, policy)
+}
+
+// DecodeMsg - reads BucketPolicy as MessagePack from r.
+func (policy *BucketPolicy) DecodeMsg(r *msgp.Reader) error {
+	return decodeMsg(r, policy)
+}
+
+// Msgsize - returns the maximum serialized size of BucketPolicy in bytes.
+func (policy BucketPolicy) Msgsize() int {
+	s := msgp.MapHeaderSize + 3*msgp.StringPrefixSize + len("IDVersionStatement") +
+		msgp.StringPrefixSize + len(policy.ID) + msgp.StringPrefixSize + len(policy.Version) +
+		msgp.ArrayHeaderSize
+	for _, statement := range policy.Statements {
+		s += statement.Msgsize()
+	}
+	return s
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+func TestPolicyMsgp(t *testing.T) {
+	testCases := []struct {
+		data string
+	}{
+		{`{"Version": "2012-10-17", "Statement": []}`},
+		{`{"ID": "MyPolicy", "Version": "2012-10-17", "Statement": [
+    {"Sid": "ReadOnly", "Effect": "Allow", "Action": ["s3:GetObject", "s3:ListBucket"], "Resource": ["arn:aws:s3:::mybucket", "arn:aws:s3:::mybucket/*"]},
+    {"Effect": "Deny", "Action": ["s3:GetObject"], "NotResource": ["arn:aws:s3:::mybucket/public/*"],
+     "Condition": {"NotIpAddress": {"aws:SourceIp": ["10.0.0.0/8"]}}},
+    {"Effect": "Deny", "Action": ["s3:ListBucket"], "Resource": ["arn:aws:s3:::mybucket"],
+     "Condition": {"NumericGreaterThan": {"s3:max-keys": [1.50]}}}
+]}`},
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "NotAction": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::${aws:username}/*"]},
+    {"Effect": "Allow", "Action": ["admin:ServerInfo"]},
+    {"Effect": "Allow", "Action": ["kms:Status"]},
+    {"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::*"]}
+]}`},
+	}
+
+	for i, testCase := range testCases {
+		policy := parseTestPolicy(t, testCase.data)
+
+		data, err := policy.MarshalMsg(nil)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if len(data) > policy.Msgsize() {
+			t.Fatalf("case %v: Msgsize() %v is less than encoded size %v", i+1, policy.Msgsize(), len(data))
+		}
+
+		var result Policy
+		left, err := result.UnmarshalMsg(data)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if len(left) > 0 {
+			t.Fatalf("case %v: %v bytes left over", i+1, len(left))
+		}
+		if !result.Equals(*policy) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, policy, result)
+		}
+		if result.ID != policy.ID || result.Version != policy.Version {
+			t.Fatalf("case %v: expected: %v %v, got: %v %v", i+1, policy.ID, policy.Version, result.ID, result.Version)
+		}
+		if !reflect.DeepEqual(result.actionStatementIndex, policy.actionStatementIndex) || result.hasDeny != policy.hasDeny {
+			t.Fatalf("case %v: action index is not rebuilt", i+1)
+		}
+
+		var buf bytes.Buffer
+		if err = msgp.Encode(&buf, policy); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		result = Policy{}
+		if err = msgp.Decode(&buf, &result); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if !result.Equals(*policy) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, policy, result)
+		}
+	}
+}
+
+func TestPolicyMsgpIsAllowed(t *testing.T) {
+	policy := parseTestPolicy(t, `{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject", "s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/*"]},
+    {"Effect": "Deny", "Action": ["s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/private/*"]}
+]}`)
+
+	data, err := policy.MarshalMsg(nil)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	var result Policy
+	if _, err = result.UnmarshalMsg(data); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		args           Args
+		expectedResult bool
+	}{
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true},
+		{Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true},
+		{Args{Action: PutObjectAction, BucketName: "mybucket", ObjectName: "private/myobject"}, false},
+		{Args{Action: DeleteObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false},
+	}
+
+	for i, testCase := range testCases {
+		if got := result.IsAllowed(testCase.args); got != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, got)
+		}
+	}
+}
+
+func TestBucketPolicyMsgp(t *testing.T) {
+	testCases := []struct {
+		data string
+	}{
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Sid": "Public", "Effect": "Allow", "Principal": "*", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"],
+     "Condition": {"StringEquals": {"s3:ExistingObjectTag/public": ["yes"]}}},
+    {"Effect": "Deny", "NotPrincipal": {"AWS": ["admin"]}, "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}
+]}`},
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Principal": {"AWS": ["alice", "bob"]}, "NotAction": ["s3:GetObject"], "NotResource": ["arn:aws:s3:::mybucket/private/*"]}
+]}`},
+	}
+
+	for i, testCase := range testCases {
+		policy, err := ParseBucketPolicyConfig(strings.NewReader(testCase.data), "mybucket")
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+
+		data, err := policy.MarshalMsg(nil)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if len(data) > policy.Msgsize() {
+			t.Fatalf("case %v: Msgsize() %v is less than encoded size %v", i+1, policy.Msgsize(), len(data))
+		}
+
+		var result BucketPolicy
+		left, err := result.UnmarshalMsg(data)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if len(left) > 0 {
+			t.Fatalf("case %v: %v bytes left over", i+1, len(left))
+		}
+		if !result.Equals(*policy) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, policy, result)
+		}
+		if err = result.Validate("mybucket"); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+	}
+}
+
+func TestPolicyMsgpDeterministic(t *testing.T) {
+	policy := parseTestPolicy(t, `{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject", "s3:PutObject", "s3:ListBucket", "s3:DeleteObject", "s3:GetBucketLocation"],
+     "Resource": ["arn:aws:s3:::a", "arn:aws:s3:::a/*", "arn:aws:s3:::b", "arn:aws:s3:::b/*", "arn:aws:s3:::c/*"],
+     "Condition": {"StringEquals": {"aws:username": ["alice", "bob", "carol", "dave", "eve"]}}}
+]}`)
+	bucketPolicy, err := ParseBucketPolicyConfig(strings.NewReader(`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Principal": {"AWS": ["alice", "bob", "carol", "dave", "eve"]}, "Action": ["s3:GetObject", "s3:PutObject", "s3:DeleteObject"],
+     "Resource": ["arn:aws:s3:::mybucket/a/*", "arn:aws:s3:::mybucket/b/*", "arn:aws:s3:::mybucket/c/*"]}
+]}`), "mybucket")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []msgp.Marshaler{policy, bucketPolicy}
+
+	for i, testCase := range testCases {
+		expected, err := testCase.MarshalMsg(nil)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		for range 20 {
+			data, err := testCase.MarshalMsg(nil)
+			if err != nil {
+				t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+			}
+			if !bytes.Equal(data, expected) {
+				t.Fatalf("case %v: expected: %x, got: %x", i+1, expected, data)
+			}
+		}
+	}
+}
+
+func TestPolicyUnmarshalMsgError(t *testing.T) {
+	policy := parseTestPolicy(t, `{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}
+]}`)
+	data, err := policy.MarshalMsg(nil)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	// Unknown fields are skipped.
+	extra := msgp.AppendMapHeader(nil, 2)
+	extra = msgp.AppendString(extra, "Future")
+	extra = msgp.AppendInt(extra, 1)
+	extra = msgp.AppendString(extra, "Statement")
+	extra = msgp.AppendArrayHeader(extra, 0)
+
+	invalidResource := msgp.AppendMapHeader(nil, 1)
+	invalidResource = msgp.AppendString(invalidResource, "Statement")
+	invalidResource = msgp.AppendArrayHeader(invalidResource, 1)
+	invalidResource = msgp.AppendMapHeader(invalidResource, 1)
+	invalidResource = msgp.AppendString(invalidResource, "Resource")
+	invalidResource = msgp.AppendArrayHeader(invalidResource, 1)
+	invalidResource = msgp.AppendString(invalidResource, "mybucket/*")
+
+	testCases := []struct {
+		data      []byte
+		expectErr bool
+	}{
+		{data, false},
+		{extra, false},
+		{data[:len(data)-1], true},
+		{invalidResource, true},
+		{msgp.AppendString(nil, "policy"), true},
+	}
+
+	for i, testCase := range testCases {
+		var result Policy
+		_, err := result.UnmarshalMsg(testCase.data)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v\n", i+1, testCase.expectErr, expectErr)
+		}
+	}
+}
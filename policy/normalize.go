@@ -0,0 +1,236 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Normalize - returns an equivalent policy in canonical form, e.g. to show
+// the result of MergePolicies(). It
+//   - removes actions and resources covered by a broader wildcard pattern
+//     in the same statement,
+//   - merges the actions of statements with the same SID, effect, resources
+//     and conditions,
+//   - merges the resources of statements with the same SID, effect, actions
+//     and conditions,
+//   - removes statements covered by another statement with the same effect,
+//   - sorts the statements by their JSON encoding.
+//
+// Statements with NotAction are never merged by actions, and statements with
+// NotResource never by resources.
+func (iamp Policy) Normalize() Policy {
+	statements := make([]Statement, 0, len(iamp.Statements))
+	for _, st := range iamp.Statements {
+		st = st.Clone()
+		st.Actions = compactActions(st.Actions)
+		st.NotActions = compactActions(st.NotActions)
+		st.Resources = compactResources(st.Resources)
+		st.NotResources = compactResources(st.NotResources)
+		statements = append(statements, st)
+	}
+
+	for {
+		n := len(statements)
+		statements = mergeStatements(statements, actionsMergeKey, func(dst *Statement, src Statement) {
+			for action := range src.Actions {
+				dst.Actions.Add(action)
+			}
+			dst.Actions = compactActions(dst.Actions)
+		})
+		statements = mergeStatements(statements, resourcesMergeKey, func(dst *Statement, src Statement) {
+			for resource := range src.Resources {
+				dst.Resources.Add(resource)
+			}
+			dst.Resources = compactResources(dst.Resources)
+		})
+		if len(statements) == n {
+			break
+		}
+	}
+
+	statements = dropCoveredStatements(statements)
+
+	sort.SliceStable(statements, func(i, j int) bool {
+		return statementSortKey(statements[i]) < statementSortKey(statements[j])
+	})
+
+	policy := Policy{
+		ID:         iamp.ID,
+		Version:    iamp.Version,
+		Statements: statements,
+	}
+	policy.updateActionIndex()
+	return policy
+}
+
+func statementSortKey(st Statement) string {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return string(st.SID) + string(st.Effect) + st.Actions.String() + st.Resources.String()
+	}
+	return string(data)
+}
+
+func conditionsKey(st Statement) string {
+	data, err := json.Marshal(st.Conditions)
+	if err != nil {
+		return st.Conditions.String()
+	}
+	return string(data)
+}
+
+// statementClass - returns the kind of actions of the statement, as
+// statements cannot mix actions of different kinds.
+func (statement Statement) statementClass() string {
+	switch {
+	case statement.isAdmin():
+		return "admin"
+	case statement.isSTS():
+		return "sts"
+	case statement.isKMS():
+		return "kms"
+	}
+	return "s3"
+}
+
+// actionsMergeKey - returns the key of statements whose actions can be
+// merged, or false if the statement cannot be merged.
+func actionsMergeKey(st Statement) (string, bool) {
+	if len(st.Actions) == 0 || len(st.NotActions) > 0 {
+		return "", false
+	}
+	return strings.Join([]string{
+		string(st.SID), string(st.Effect), st.statementClass(),
+		st.Resources.String(), st.NotResources.String(), conditionsKey(st),
+	}, "\x00"), true
+}
+
+// resourcesMergeKey - returns the key of statements whose resources can be
+// merged, or false if the statement cannot be merged.
+func resourcesMergeKey(st Statement) (string, bool) {
+	if len(st.Resources) == 0 || len(st.NotResources) > 0 || st.isAdmin() || st.isSTS() {
+		return "", false
+	}
+	return strings.Join([]string{
+		string(st.SID), string(st.Effect),
+		st.Actions.String(), st.NotActions.String(), conditionsKey(st),
+	}, "\x00"), true
+}
+
+// mergeStatements - merges statements with the same key into the first of
+// them, keeping the order of the statements.
+func mergeStatements(statements []Statement, key func(Statement) (string, bool), merge func(*Statement, Statement)) []Statement {
+	result := make([]Statement, 0, len(statements))
+	index := make(map[string]int, len(statements))
+	for _, st := range statements {
+		k, ok := key(st)
+		if !ok {
+			result = append(result, st)
+			continue
+		}
+		if i, found := index[k]; found {
+			merge(&result[i], st)
+			continue
+		}
+		index[k] = len(result)
+		result = append(result, st)
+	}
+	return result
+}
+
+// dropCoveredStatements - removes statements covered by another statement
+// with the same effect, which either has no conditions or the same ones.
+func dropCoveredStatements(statements []Statement) []Statement {
+	conditions := make([]string, len(statements))
+	for i, st := range statements {
+		conditions[i] = conditionsKey(st)
+	}
+
+	result := make([]Statement, 0, len(statements))
+	for i, st := range statements {
+		covered := false
+		for j, other := range statements {
+			if i == j || other.Effect != st.Effect {
+				continue
+			}
+			if len(other.Conditions) > 0 && conditions[i] != conditions[j] {
+				continue
+			}
+			if !other.covers(st) {
+				continue
+			}
+			// Keep the first of statements covering each other, unless
+			// only one of them has conditions.
+			if !st.covers(other) || len(st.Conditions) > len(other.Conditions) || j < i {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			result = append(result, st)
+		}
+	}
+	return result
+}
+
+// compactActions - removes actions covered by another action pattern of the
+// set.
+func compactActions(actions ActionSet) ActionSet {
+	if len(actions) == 0 {
+		return actions
+	}
+	result := NewActionSet()
+	for action := range actions {
+		covered := false
+		for pattern := range actions {
+			if pattern != action && patternCovers(string(pattern), string(action)) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			result.Add(action)
+		}
+	}
+	return result
+}
+
+// compactResources - removes resources covered by another resource pattern
+// of the set.
+func compactResources(resources ResourceSet) ResourceSet {
+	if len(resources) == 0 {
+		return resources
+	}
+	result := NewResourceSet()
+	for resource := range resources {
+		covered := false
+		for pattern := range resources {
+			if pattern != resource && resourceCovers(pattern, resource) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			result.Add(resource)
+		}
+	}
+	return result
+}
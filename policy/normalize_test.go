@@ -0,0 +1,159 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPolicyNormalize(t *testing.T) {
+	testCases := []struct {
+		data           string
+		expectedResult string
+	}{
+		// merge actions, then resources.
+		{
+			`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:PutObject"], "Resource": ["arn:aws:s3:::b/*"]},
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::a/*"]},
+    {"Effect": "Allow", "Action": ["s3:PutObject"], "Resource": ["arn:aws:s3:::a/*"]},
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::b/*"]}
+]}`,
+			`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:PutObject"],"Resource":["arn:aws:s3:::a/*","arn:aws:s3:::b/*"]}]}`,
+		},
+		// subsumed actions, resources and statements.
+		{
+			`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Deny", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::a/x/*"]},
+    {"Effect": "Allow", "Action": ["s3:Get*", "s3:GetObject"], "Resource": ["arn:aws:s3:::a/x/*", "arn:aws:s3:::a/*", "arn:aws:s3:::a/y"]},
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::a/z/*"],
+     "Condition": {"StringEquals": {"aws:username": "alice"}}},
+    {"Effect": "Deny", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::a/x/*"]}
+]}`,
+			`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:Get*"],"Resource":["arn:aws:s3:::a/*"]},{"Effect":"Deny","Action":["s3:DeleteObject"],"Resource":["arn:aws:s3:::a/x/*"]}]}`,
+		},
+		// statements with different conditions, SIDs or kinds of actions are not merged.
+		{
+			`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::a/*"],
+     "Condition": {"StringEquals": {"aws:username": ["bob", "alice"]}}},
+    {"Effect": "Allow", "Action": ["s3:PutObject"], "Resource": ["arn:aws:s3:::a/*"]},
+    {"Sid": "Named", "Effect": "Allow", "Action": ["s3:ListBucket"], "Resource": ["arn:aws:s3:::a/*"]},
+    {"Effect": "Allow", "Action": ["admin:ServerInfo"]},
+    {"Effect": "Allow", "Action": ["kms:Status"]}
+]}`,
+			`{"Version":"2012-10-17","Statement":[` +
+				`{"Effect":"Allow","Action":["admin:ServerInfo"]},` +
+				`{"Effect":"Allow","Action":["kms:Status"]},` +
+				`{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::a/*"],"Condition":{"StringEquals":{"aws:username":["alice","bob"]}}},` +
+				`{"Effect":"Allow","Action":["s3:PutObject"],"Resource":["arn:aws:s3:::a/*"]},` +
+				`{"Sid":"Named","Effect":"Allow","Action":["s3:ListBucket"],"Resource":["arn:aws:s3:::a/*"]}]}`,
+		},
+		// NotAction and NotResource.
+		{
+			`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "NotAction": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::a/*"]},
+    {"Effect": "Allow", "NotAction": ["s3:PutObject"], "Resource": ["arn:aws:s3:::a/*"]},
+    {"Effect": "Deny", "Action": ["s3:GetObject"], "NotResource": ["arn:aws:s3:::a/*"]},
+    {"Effect": "Deny", "Action": ["s3:PutObject"], "NotResource": ["arn:aws:s3:::a/*"]}
+]}`,
+			`{"Version":"2012-10-17","Statement":[` +
+				`{"Effect":"Allow","NotAction":["s3:DeleteObject"],"Resource":["arn:aws:s3:::a/*"]},` +
+				`{"Effect":"Allow","NotAction":["s3:PutObject"],"Resource":["arn:aws:s3:::a/*"]},` +
+				`{"Effect":"Deny","Action":["s3:GetObject","s3:PutObject"],"NotResource":["arn:aws:s3:::a/*"]}]}`,
+		},
+	}
+
+	for i, testCase := range testCases {
+		policy := parseTestPolicy(t, testCase.data)
+		normalized := policy.Normalize()
+		if err := normalized.Validate(); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+
+		data, err := json.Marshal(normalized)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if string(data) != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, string(data))
+		}
+
+		if again := normalized.Normalize(); !again.Equals(normalized) {
+			t.Fatalf("case %v: expected normalization to be idempotent", i+1)
+		}
+	}
+}
+
+func TestPolicyNormalizeEquivalence(t *testing.T) {
+	policy := MergePolicies(
+		DefaultPolicies[1].Definition,
+		DefaultPolicies[2].Definition,
+		*parseTestPolicy(t, `{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:ListBucket"], "Resource": ["arn:aws:s3:::mybucket"]},
+    {"Effect": "Allow", "Action": ["s3:ListBucket"], "Resource": ["arn:aws:s3:::other"]},
+    {"Effect": "Allow", "Action": ["s3:GetObjectVersion", "s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/data/*"]},
+    {"Effect": "Deny", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/data/secret*"]},
+    {"Effect": "Deny", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/data/secret/*"]}
+]}`),
+		*parseTestPolicy(t, `{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:PutObject"], "Resource": ["arn:aws:s3:::home/${aws:username}/*"]},
+    {"Effect": "Allow", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::home/${aws:username}/*"]},
+    {"Effect": "Deny", "Action": ["s3:DeleteObject"], "NotResource": ["arn:aws:s3:::home/*"]},
+    {"Effect": "Allow", "NotAction": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::scratch/?a*"]},
+    {"Effect": "Allow", "Action": ["admin:ServerInfo"]},
+    {"Effect": "Deny", "Action": ["admin:CreateUser"]},
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::ip/*"],
+     "Condition": {"IpAddress": {"aws:SourceIp": "192.168.1.0/24"}}},
+    {"Effect": "Allow", "Action": ["s3:PutObject"], "Resource": ["arn:aws:s3:::ip/*"],
+     "Condition": {"IpAddress": {"aws:SourceIp": "192.168.1.0/24"}}}
+]}`),
+	)
+	normalized := policy.Normalize()
+	if len(normalized.Statements) >= len(policy.Statements) {
+		t.Fatalf("expected fewer statements, got %v of %v", len(normalized.Statements), len(policy.Statements))
+	}
+
+	resources := [][2]string{
+		{"mybucket", ""}, {"mybucket", "data/a"}, {"mybucket", "data/secret/a"}, {"mybucket", "data/secrets"},
+		{"other", ""}, {"home", "alice/a"}, {"home", "bob/a"}, {"scratch", "xa"}, {"ip", "a"}, {"", ""},
+	}
+	conditions := []map[string][]string{
+		nil,
+		{"username": {"alice"}, "SourceIp": {"192.168.1.10"}},
+	}
+	for _, action := range supportedActionList() {
+		for _, resource := range resources {
+			for _, conds := range conditions {
+				for _, denyOnly := range []bool{false, true} {
+					args := Args{
+						Action:          action,
+						BucketName:      resource[0],
+						ObjectName:      resource[1],
+						ConditionValues: conds,
+						DenyOnly:        denyOnly,
+					}
+					if expected, got := policy.IsAllowed(args), normalized.IsAllowed(args); expected != got {
+						t.Fatalf("%v on %v with %v: expected: %v, got: %v", action, resource, conds, expected, got)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/minio/pkg/v3/wildcard"
+)
+
+// KeyRule - object keys of a bucket matched by a single statement.
+type KeyRule struct {
+	// Patterns are object key patterns, which may contain '*' and '?'
+	// wildcards.
+	Patterns []string `json:"patterns"`
+	// Not is true if the rule matches the keys matching none of Patterns,
+	// i.e. the statement uses NotResource.
+	Not bool `json:"not,omitempty"`
+	// Conditional is true if the statement has conditions, so the rule
+	// applies to some requests only.
+	Conditional bool `json:"conditional,omitempty"`
+}
+
+// Match - returns whether the rule matches the given object key, ignoring
+// conditions.
+func (rule KeyRule) Match(key string) bool {
+	for _, pattern := range rule.Patterns {
+		if cp := path.Clean(key); cp != "." && cp == pattern || wildcard.Match(pattern, key) {
+			return !rule.Not
+		}
+	}
+	return rule.Not
+}
+
+// ObjectFilter - object keys of a bucket for which policies allow or deny
+// an action. A key is allowed if it matches an Allow rule and no Deny rule.
+type ObjectFilter struct {
+	Allow []KeyRule `json:"allow,omitempty"`
+	Deny  []KeyRule `json:"deny,omitempty"`
+}
+
+// Conditional - returns whether any rule has conditions, i.e. the filter
+// alone cannot decide for some keys.
+func (f ObjectFilter) Conditional() bool {
+	for _, rule := range slices.Concat(f.Allow, f.Deny) {
+		if rule.Conditional {
+			return true
+		}
+	}
+	return false
+}
+
+// Match - returns whether the action is allowed for the given object key.
+// If conditional is true, the outcome depends on conditions and the request
+// has to be checked by IsAllowed() instead.
+func (f ObjectFilter) Match(key string) (allowed, conditional bool) {
+	for _, rule := range f.Deny {
+		if rule.Match(key) {
+			if !rule.Conditional {
+				return false, false
+			}
+			conditional = true
+		}
+	}
+
+	for _, rule := range f.Allow {
+		if !rule.Match(key) {
+			continue
+		}
+		if !rule.Conditional {
+			return !conditional, conditional
+		}
+		conditional = true
+	}
+	return false, conditional
+}
+
+// Prefixes - returns the shortest literal prefixes of all keys which may be
+// allowed, ignoring Deny rules and conditions, so that listing can be
+// restricted to them. An empty prefix means keys anywhere in the bucket may
+// be allowed, no prefixes mean that no key is allowed.
+func (f ObjectFilter) Prefixes() []string {
+	var prefixes []string
+	for _, rule := range f.Allow {
+		if rule.Not {
+			return []string{""}
+		}
+		for _, pattern := range rule.Patterns {
+			prefixes = append(prefixes, literalPrefix(pattern))
+		}
+	}
+	sort.Strings(prefixes)
+
+	// Drop prefixes having another one as a prefix, which sorts before them.
+	result := prefixes[:0]
+	for _, prefix := range prefixes {
+		if len(result) == 0 || !strings.HasPrefix(prefix, result[len(result)-1]) {
+			result = append(result, prefix)
+		}
+	}
+	return result
+}
+
+// keyPatterns - returns the object key patterns matching the keys k for
+// which the resource pattern matches prefix+k.
+func keyPatterns(pattern, prefix string) []string {
+	var patterns []string
+	// Every star branches the walk, so each pair of pattern and prefix
+	// offsets is walked once only to bound the time by the product of their
+	// lengths.
+	visited := make(map[[2]int]bool)
+	var walk func(i, j int)
+	walk = func(i, j int) {
+		for ; j < len(prefix); i, j = i+1, j+1 {
+			if i == len(pattern) || visited[[2]int{i, j}] {
+				return
+			}
+			visited[[2]int{i, j}] = true
+
+			switch pattern[i] {
+			case '*':
+				// Either the star matches nothing more or it matches the
+				// next byte.
+				walk(i+1, j)
+				i--
+			case '?':
+			default:
+				if pattern[i] != prefix[j] {
+					return
+				}
+			}
+		}
+		if i < len(pattern) {
+			patterns = append(patterns, pattern[i:])
+		}
+	}
+	walk(0, 0)
+
+	if slices.Contains(patterns, "*") {
+		return []string{"*"}
+	}
+	sort.Strings(patterns)
+	return slices.Compact(patterns)
+}
+
+// keyRule - returns the rule matching the object keys of args.BucketName
+// for which the statement applies to args.Action; ok is false if there are
+// none. It follows IsAllowedPtr().
+func (statement Statement) keyRule(args *Args) (rule KeyRule, ok bool) {
+	if (!statement.Actions.Match(args.Action) && !statement.Actions.IsEmpty()) ||
+		statement.NotActions.Match(args.Action) {
+		return rule, false
+	}
+
+	rule.Conditional = len(statement.Conditions) > 0
+	if statement.ignoresResources() || statement.isKMS() && len(statement.Resources) == 0 {
+		rule.Patterns = []string{"*"}
+		return rule, true
+	}
+
+	prefix := args.BucketName + "/"
+	resources := statement.Resources
+	if len(resources) == 0 {
+		rule.Not = true
+		resources = statement.NotResources
+	}
+	for resource := range resources {
+		rule.Patterns = append(rule.Patterns, keyPatterns(resource.substitute(args.ConditionValues), prefix)...)
+	}
+	sort.Strings(rule.Patterns)
+	rule.Patterns = slices.Compact(rule.Patterns)
+
+	if rule.Not && len(rule.Patterns) == 0 {
+		// No NotResource pattern matches keys of the bucket.
+		rule.Not = false
+		rule.Patterns = []string{"*"}
+	}
+	return rule, len(rule.Patterns) > 0
+}
+
+// ObjectFilter - returns the object keys of args.BucketName for which the
+// policy allows or denies args.Action, taking Resource and NotResource into
+// account. Policy variables in resources are replaced by their values in
+// args.ConditionValues, args.ObjectName is ignored.
+func (iamp *Policy) ObjectFilter(args Args) ObjectFilter {
+	var f ObjectFilter
+	if args.BucketName == "" {
+		return f
+	}
+
+	if args.DenyOnly || args.IsOwner {
+		f.Allow = append(f.Allow, KeyRule{Patterns: []string{"*"}})
+	}
+	for _, statement := range iamp.Statements {
+		rule, ok := statement.keyRule(&args)
+		if !ok {
+			continue
+		}
+		switch statement.Effect {
+		case Allow:
+			f.Allow = append(f.Allow, rule)
+		case Deny:
+			f.Deny = append(f.Deny, rule)
+		}
+	}
+	return f
+}
+
+// ObjectFilterPolicies - returns the object keys of args.BucketName for
+// which the given policies allow or deny args.Action, like
+// IsAllowedSerial().
+func ObjectFilterPolicies(policies []Policy, args Args) ObjectFilter {
+	var f ObjectFilter
+	for _, policy := range policies {
+		pf := policy.ObjectFilter(args)
+		f.Allow = append(f.Allow, pf.Allow...)
+		f.Deny = append(f.Deny, pf.Deny...)
+	}
+	return f
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKeyPatterns(t *testing.T) {
+	testCases := []struct {
+		pattern        string
+		expectedResult []string
+	}{
+		{"mybucket/photos/*", []string{"photos/*"}},
+		{"mybucket/photos", []string{"photos"}},
+		{"mybucket", nil},
+		{"yourbucket/*", nil},
+		{"*", []string{"*"}},
+		{"my*", []string{"*"}},
+		{"*/photos/*", []string{"*/photos/*", "photos/*"}},
+		{"mybucke?/a?c", []string{"a?c"}},
+		{"mybucket/*/x", []string{"*/x"}},
+		{"mybucket/**/x", []string{"**/x"}},
+		{strings.Repeat("*", 40), []string{"*"}},
+	}
+
+	for i, testCase := range testCases {
+		result := keyPatterns(testCase.pattern, "mybucket/")
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+	// Many stars must not make the walk exponential.
+	var expectedResult []string
+	for n := 40; n > 0; n-- {
+		expectedResult = append(expectedResult, strings.Repeat("*", n)+"mybucket/x")
+	}
+	expectedResult = append(expectedResult, "x")
+	if result := keyPatterns(strings.Repeat("*", 40)+"mybucket/x", "mybucket/"); !reflect.DeepEqual(result, expectedResult) {
+		t.Fatalf("expected: %v, got: %v", expectedResult, result)
+	}
+}
+
+func TestPolicyObjectFilter(t *testing.T) {
+	testCases := []struct {
+		data             string
+		expectedPrefixes []string
+		conditional      bool
+	}{
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/public/*", "arn:aws:s3:::mybucket/shared/*"]},
+    {"Effect": "Deny", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/public/secret*"]}
+]}`, []string{"public/", "shared/"}, false},
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::*"]},
+    {"Effect": "Deny", "Action": ["s3:GetObject"], "NotResource": ["arn:aws:s3:::mybucket/public/*"]}
+]}`, []string{""}, false},
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "NotResource": ["arn:aws:s3:::mybucket/private/*", "arn:aws:s3:::otherbucket/*"]}
+]}`, []string{""}, false},
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/home/${aws:username}/*", "arn:aws:s3:::mybucket/home/shared*"]}
+]}`, []string{"home/alice/", "home/shared"}, false},
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/*"]},
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::otherbucket/*"]}
+]}`, nil, false},
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"]},
+    {"Effect": "Deny", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/private/*"],
+     "Condition": {"NotIpAddress": {"aws:SourceIp": ["10.0.0.0/8"]}}}
+]}`, []string{""}, true},
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::*/photos/*"]}
+]}`, []string{""}, false},
+		// many stars.
+		{`{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::******************************"]}
+]}`, []string{""}, false},
+	}
+
+	keys := []string{
+		"public/a.txt", "public/secret.txt", "shared/b", "private/c", "other",
+		"home/alice/x", "home/bob/y", "home/shared-z", "photos/p.jpg", "2026/photos/q.jpg",
+	}
+	conditionValues := map[string][]string{"username": {"alice"}, "SourceIp": {"192.168.1.10"}}
+
+	for i, testCase := range testCases {
+		policy := parseTestPolicy(t, testCase.data)
+		args := Args{
+			Action:          GetObjectAction,
+			BucketName:      "mybucket",
+			ConditionValues: conditionValues,
+		}
+		f := policy.ObjectFilter(args)
+
+		if prefixes := f.Prefixes(); !reflect.DeepEqual(prefixes, testCase.expectedPrefixes) {
+			t.Fatalf("case %v: prefixes: expected: %v, got: %v", i+1, testCase.expectedPrefixes, prefixes)
+		}
+		if conditional := f.Conditional(); conditional != testCase.conditional {
+			t.Fatalf("case %v: conditional: expected: %v, got: %v", i+1, testCase.conditional, conditional)
+		}
+
+		// The filter must agree with IsAllowed() whenever it can decide.
+		for _, key := range keys {
+			allowed, conditional := f.Match(key)
+			if conditional {
+				continue
+			}
+			args.ObjectName = key
+			if expected := policy.IsAllowed(args); allowed != expected {
+				t.Fatalf("case %v: key %v: expected: %v, got: %v", i+1, key, expected, allowed)
+			}
+		}
+	}
+}
+
+func TestObjectFilterMatch(t *testing.T) {
+	f := ObjectFilter{
+		Allow: []KeyRule{
+			{Patterns: []string{"public/*"}},
+			{Patterns: []string{"team/*"}, Conditional: true},
+		},
+		Deny: []KeyRule{
+			{Patterns: []string{"public/secret*"}},
+			{Patterns: []string{"public/internal/*"}, Conditional: true},
+			{Patterns: []string{"public/*", "team/*"}, Not: true},
+		},
+	}
+
+	testCases := []struct {
+		key                 string
+		expectedAllowed     bool
+		expectedConditional bool
+	}{
+		{"public/a", true, false},
+		{"public/secret", false, false},
+		{"public/internal/a", false, true},
+		{"team/a", false, true},
+		{"private/a", false, false},
+	}
+
+	for i, testCase := range testCases {
+		allowed, conditional := f.Match(testCase.key)
+		if allowed != testCase.expectedAllowed || conditional != testCase.expectedConditional {
+			t.Fatalf("case %v: expected: %v %v, got: %v %v", i+1, testCase.expectedAllowed, testCase.expectedConditional, allowed, conditional)
+		}
+	}
+}
+
+func TestObjectFilterPolicies(t *testing.T) {
+	policies := []Policy{
+		*parseTestPolicy(t, `{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/a/*"]}
+]}`),
+		*parseTestPolicy(t, `{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/b/*"]},
+    {"Effect": "Deny", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/a/private/*"]}
+]}`),
+	}
+
+	args := Args{Action: GetObjectAction, BucketName: "mybucket"}
+	f := ObjectFilterPolicies(policies, args)
+	if prefixes := f.Prefixes(); !reflect.DeepEqual(prefixes, []string{"a/", "b/"}) {
+		t.Fatalf("expected: %v, got: %v", []string{"a/", "b/"}, prefixes)
+	}
+
+	for _, key := range []string{"a/x", "a/private/x", "b/x", "c/x"} {
+		allowed, _ := f.Match(key)
+		args.ObjectName = key
+		if expected := IsAllowedSerial(policies, args); allowed != expected {
+			t.Fatalf("key %v: expected: %v, got: %v", key, expected, allowed)
+		}
+	}
+}
@@ -18,6 +18,7 @@
 package policy
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/binary"
@@ -26,6 +27,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/pkg/v3/wildcard"
@@ -140,41 +142,37 @@ func (iamp Policy) MatchResource(resource string) bool {
 	return false
 }
 
+// actionArgs - returns args to check whether the given action is allowed on
+// the given resource, as used for enumerating supported actions.
+func actionArgs(action Action, bucketName, objectName string, conditionValues map[string][]string) Args {
+	return Args{
+		BucketName:      bucketName,
+		ObjectName:      objectName,
+		Action:          action,
+		ConditionValues: conditionValues,
+		// checks mainly for actions that can have explicit
+		// deny, while without it are implicitly enabled.
+		DenyOnly: action == CreateServiceAccountAdminAction || action == CreateUserAdminAction,
+	}
+}
+
 // IsAllowedActions returns all supported actions for this policy.
 func (iamp Policy) IsAllowedActions(bucketName, objectName string, conditionValues map[string][]string) ActionSet {
 	actionSet := make(ActionSet)
 	for action := range supportedActions {
-		if iamp.IsAllowed(Args{
-			BucketName:      bucketName,
-			ObjectName:      objectName,
-			Action:          action,
-			ConditionValues: conditionValues,
-		}) {
+		if iamp.IsAllowed(actionArgs(action, bucketName, objectName, conditionValues)) {
 			actionSet.Add(action)
 		}
 	}
 	for action := range supportedAdminActions {
 		admAction := Action(action)
-		if iamp.IsAllowed(Args{
-			BucketName:      bucketName,
-			ObjectName:      objectName,
-			Action:          admAction,
-			ConditionValues: conditionValues,
-			// checks mainly for actions that can have explicit
-			// deny, while without it are implicitly enabled.
-			DenyOnly: action == CreateServiceAccountAdminAction || action == CreateUserAdminAction,
-		}) {
+		if iamp.IsAllowed(actionArgs(admAction, bucketName, objectName, conditionValues)) {
 			actionSet.Add(admAction)
 		}
 	}
 	for action := range supportedKMSActions {
 		kmsAction := Action(action)
-		if iamp.IsAllowed(Args{
-			BucketName:      bucketName,
-			ObjectName:      objectName,
-			Action:          kmsAction,
-			ConditionValues: conditionValues,
-		}) {
+		if iamp.IsAllowed(actionArgs(kmsAction, bucketName, objectName, conditionValues)) {
 			actionSet.Add(kmsAction)
 		}
 	}
@@ -200,19 +198,96 @@ func IsAllowedSerial(policies []Policy, args Args) bool {
 	return gotAllow
 }
 
+// PolicyEvaluation - outcome of evaluating a single policy, as reported to
+// an Observer.
+type PolicyEvaluation struct {
+	// Index is the index of the policy in the evaluated policies.
+	Index    int
+	Decision Decision
+	// Statements is the number of statements evaluated to reach the
+	// decision.
+	Statements int
+	Duration   time.Duration
+}
+
+// Observer - receives callbacks from IsAllowedSerialContext() and
+// IsAllowedParContext(), for example to attribute the latency of
+// authorization to specific policies. Callbacks may be made concurrently by
+// IsAllowedParContext() and must not block.
+type Observer interface {
+	// PolicyEvaluated - called after each evaluated policy.
+	PolicyEvaluated(ctx context.Context, eval PolicyEvaluation)
+	// Denied - called once with the policy denying the request, which
+	// stops the evaluation of the remaining policies.
+	Denied(ctx context.Context, eval PolicyEvaluation)
+}
+
+// evaluate - decides the policy with given index, reporting to observer if
+// set.
+func evaluate(ctx context.Context, policies []Policy, index int, args *Args, observer Observer) PolicyEvaluation {
+	eval := PolicyEvaluation{Index: index}
+	if observer == nil {
+		eval.Decision, eval.Statements = policies[index].decide(args)
+		return eval
+	}
+
+	start := time.Now()
+	eval.Decision, eval.Statements = policies[index].decide(args)
+	eval.Duration = time.Since(start)
+	observer.PolicyEvaluated(ctx, eval)
+	return eval
+}
+
+// IsAllowedSerialContext - checks if the given Args is allowed by any one of
+// the given policies in serial, like IsAllowedSerial(). The evaluation
+// stops with the context error if ctx is done before a decision is made.
+// observer may be nil.
+func IsAllowedSerialContext(ctx context.Context, policies []Policy, args Args, observer Observer) (bool, error) {
+	gotAllow := false
+	for i := range policies {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		default:
+		}
+
+		eval := evaluate(ctx, policies, i, &args, observer)
+		if eval.Decision == DenyDecision {
+			if observer != nil {
+				observer.Denied(ctx, eval)
+			}
+			return false, nil
+		}
+		if eval.Decision == AllowDecision {
+			gotAllow = true
+		}
+	}
+	return gotAllow, nil
+}
+
 // IsAllowedPar - checks if the given Args is allowed by any one of the given
 // policies in parallel (when len(policies) > 100).
 func IsAllowedPar(policies []Policy, args Args) bool {
+	allowed, _ := IsAllowedParContext(context.Background(), policies, args, nil)
+	return allowed
+}
+
+// IsAllowedParContext - checks if the given Args is allowed by any one of
+// the given policies in parallel, like IsAllowedPar(). The evaluation stops
+// with the context error if ctx is done before a decision is made. observer
+// may be nil.
+func IsAllowedParContext(ctx context.Context, policies []Policy, args Args, observer Observer) (bool, error) {
 	if len(policies) == 0 {
-		return false
+		return false, nil
 	}
 
 	// If there is only one policy, use it directly.
 	if len(policies) == 1 {
-		return policies[0].IsAllowed(args)
+		return IsAllowedSerialContext(ctx, policies, args, observer)
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	parentCtx := ctx
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	// This must be at least 1.
@@ -231,7 +306,7 @@ func IsAllowedPar(policies []Policy, args Args) bool {
 	}
 	close(jobs)
 
-	resultCh := make(chan Decision, len(policies))
+	resultCh := make(chan PolicyEvaluation, len(policies))
 
 	var wg sync.WaitGroup
 	wg.Add(numWorkers)
@@ -246,14 +321,14 @@ func IsAllowedPar(policies []Policy, args Args) bool {
 				}
 
 				maxJ := min(i+numPoliciesPerWorker, len(policies))
-				res := NoDecision
+				res := PolicyEvaluation{Decision: NoDecision}
 				for j := i; j < maxJ; j++ {
-					decision := policies[j].Decide(&args)
-					if decision == DenyDecision {
-						res = DenyDecision
+					eval := evaluate(parentCtx, policies, j, &args, observer)
+					if eval.Decision == DenyDecision {
+						res = eval
 						break
-					} else if decision == AllowDecision {
-						res = AllowDecision
+					} else if eval.Decision == AllowDecision {
+						res.Decision = AllowDecision
 					}
 				}
 
@@ -268,19 +343,29 @@ func IsAllowedPar(policies []Policy, args Args) bool {
 
 	gotAllow := false
 	for range numJobs {
-		res := <-resultCh
-		if res == DenyDecision {
+		var res PolicyEvaluation
+		select {
+		case res = <-resultCh:
+		case <-parentCtx.Done():
 			cancel()
 			wg.Wait()
-			return false
+			return false, parentCtx.Err()
 		}
-		if res == AllowDecision {
+		if res.Decision == DenyDecision {
+			cancel()
+			wg.Wait()
+			if observer != nil {
+				observer.Denied(parentCtx, res)
+			}
+			return false, nil
+		}
+		if res.Decision == AllowDecision {
 			gotAllow = true
 		}
 	}
 
 	wg.Wait()
-	return gotAllow
+	return gotAllow, nil
 }
 
 // Decision is an enum type representing the decision made by the policy
@@ -294,14 +379,56 @@ const (
 	DenyDecision
 )
 
+func (d Decision) String() string {
+	switch d {
+	case AllowDecision:
+		return "Allow"
+	case DenyDecision:
+		return "Deny"
+	}
+	return "NoDecision"
+}
+
+// MarshalText - encodes Decision to its string representation.
+func (d Decision) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText - decodes Decision from its string representation.
+func (d *Decision) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "allow":
+		*d = AllowDecision
+	case "deny":
+		*d = DenyDecision
+	case "nodecision":
+		*d = NoDecision
+	default:
+		return Errorf("invalid decision '%s'", text)
+	}
+	return nil
+}
+
 // Decide - decides whether the given args is allowed or not. If no policy
 // statement explicitly allows or denies the operation in the Args, it returns
 // `noDecision`. It is upto the caller to handle such cases.
 func (iamp *Policy) Decide(args *Args) Decision {
+	decision, _ := iamp.decide(args)
+	return decision
+}
+
+// decide - returns the decision of Decide() and the number of statements
+// evaluated to reach it.
+func (iamp *Policy) decide(args *Args) (Decision, int) {
+	var evaluated int
+
 	// Check all deny statements. If any one statement denies, return false.
 	for _, statement := range iamp.Statements {
-		if statement.Effect == Deny && !statement.IsAllowedPtr(args) {
-			return DenyDecision
+		if statement.Effect == Deny {
+			evaluated++
+			if !statement.IsAllowedPtr(args) {
+				return DenyDecision, evaluated
+			}
 		}
 	}
 
@@ -311,12 +438,12 @@ func (iamp *Policy) Decide(args *Args) Decision {
 	// specific scenarios where we only want to validate
 	// 'Deny' only policies.
 	if args.DenyOnly {
-		return AllowDecision
+		return AllowDecision, evaluated
 	}
 
 	// For owner, its allowed by default.
 	if args.IsOwner {
-		return AllowDecision
+		return AllowDecision, evaluated
 	}
 
 	// Check all allow statements. If any one statement allows, return true.
@@ -324,20 +451,26 @@ func (iamp *Policy) Decide(args *Args) Decision {
 		if indexes, ok := iamp.actionStatementIndex[args.Action]; ok {
 			for _, index := range indexes {
 				statement := iamp.Statements[index]
-				if statement.Effect == Allow && statement.IsAllowedPtr(args) {
-					return AllowDecision
+				if statement.Effect == Allow {
+					evaluated++
+					if statement.IsAllowedPtr(args) {
+						return AllowDecision, evaluated
+					}
 				}
 			}
 		}
 	}
 
 	for _, statement := range iamp.Statements {
-		if statement.Effect == Allow && statement.IsAllowedPtr(args) {
-			return AllowDecision
+		if statement.Effect == Allow {
+			evaluated++
+			if statement.IsAllowedPtr(args) {
+				return AllowDecision, evaluated
+			}
 		}
 	}
 
-	return NoDecision
+	return NoDecision, evaluated
 }
 
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
@@ -524,13 +657,31 @@ func (iamp *Policy) updateActionIndex() {
 func ParseConfig(reader io.Reader) (*Policy, error) {
 	var iamp Policy
 
-	decoder := json.NewDecoder(reader)
-	decoder.DisallowUnknownFields()
-	if err := decoder.Decode(&iamp); err != nil {
+	data, err := io.ReadAll(reader)
+	if err != nil {
 		return nil, Errorf("%w", err)
 	}
 
-	return &iamp, iamp.Validate()
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&iamp); err != nil {
+		return nil, locateError[Statement](data, err, checkStatement)
+	}
+
+	if err = iamp.Validate(); err != nil {
+		return &iamp, locateError[Statement](data, err, checkStatement)
+	}
+	return &iamp, nil
+}
+
+// checkStatement - decodes and validates a single statement, as done by
+// ParseConfig().
+func checkStatement(data []byte) error {
+	var statement Statement
+	if err := json.Unmarshal(data, &statement); err != nil {
+		return err
+	}
+	return statement.isValid()
 }
 
 // Equals returns true if the two policies are identical
@@ -495,6 +495,14 @@ func BenchmarkSerialEvalVsParEval(b *testing.B) {
 		}
 	}
 
+	compiledEval := func(compiled *CompiledPolicies, args []Args, expected []bool) {
+		for i, args := range args {
+			if compiled.IsAllowed(args) != expected[i] {
+				b.Errorf("Expected %v for args %v, got %v", expected[i], args, !expected[i])
+			}
+		}
+	}
+
 	for i, testCase := range testCases {
 		b.Run(fmt.Sprintf("TestCase_%d_%dp_%da", i, len(testCase.policies), len(testCase.args)), func(b *testing.B) {
 			b.Run("ParallelEval", func(b *testing.B) {
@@ -512,6 +520,15 @@ func BenchmarkSerialEvalVsParEval(b *testing.B) {
 					serialEval(testCase.policies, testCase.args, testCase.expected)
 				}
 			})
+
+			b.Run("CompiledEval", func(b *testing.B) {
+				compiled := Compile(testCase.policies)
+				b.ResetTimer()
+				b.ReportAllocs()
+				for j := 0; j < b.N; j++ {
+					compiledEval(compiled, testCase.args, testCase.expected)
+				}
+			})
 		})
 	}
 }
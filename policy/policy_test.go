@@ -19,11 +19,13 @@ package policy
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -2211,3 +2213,91 @@ func TestPolicyParseS3TablesExamples(t *testing.T) {
 		})
 	}
 }
+
+type testObserver struct {
+	mu        sync.Mutex
+	evaluated map[int]PolicyEvaluation
+	denied    []int
+}
+
+func (o *testObserver) PolicyEvaluated(_ context.Context, eval PolicyEvaluation) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.evaluated[eval.Index] = eval
+}
+
+func (o *testObserver) Denied(_ context.Context, eval PolicyEvaluation) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.denied = append(o.denied, eval.Index)
+}
+
+func TestIsAllowedContext(t *testing.T) {
+	allowPolicy := func(bucket string) Policy {
+		return *parseTestPolicy(t, `{"Version": "2012-10-17", "Statement": [
+		  {"Effect": "Deny", "Action": "s3:PutObject", "Resource": "arn:aws:s3:::`+bucket+`/*"},
+		  {"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::`+bucket+`/*"}
+		]}`)
+	}
+	denyPolicy := *parseTestPolicy(t, `{"Version": "2012-10-17", "Statement": [
+	  {"Effect": "Deny", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket7/secret/*"}
+	]}`)
+
+	var policies []Policy
+	for i := range 200 {
+		policies = append(policies, allowPolicy("bucket"+strconv.Itoa(i)))
+	}
+	policies = append(policies, denyPolicy)
+
+	testCases := []struct {
+		object          string
+		expectedResult  bool
+		expectedDenied  []int
+		expectedAllowed int
+	}{
+		{"bucket7/photo.jpg", true, nil, 7},
+		{"bucket7/secret/key", false, []int{200}, -1},
+		{"bucket300/photo.jpg", false, nil, -1},
+	}
+
+	for i, testCase := range testCases {
+		bucket, object, _ := strings.Cut(testCase.object, "/")
+		args := Args{Action: GetObjectAction, BucketName: bucket, ObjectName: object}
+
+		for _, isAllowed := range []func(context.Context, []Policy, Args, Observer) (bool, error){IsAllowedSerialContext, IsAllowedParContext} {
+			observer := &testObserver{evaluated: map[int]PolicyEvaluation{}}
+			result, err := isAllowed(context.Background(), policies, args, observer)
+			if err != nil {
+				t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+			}
+			if result != testCase.expectedResult || result != IsAllowedSerial(policies, args) {
+				t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+			}
+			if !reflect.DeepEqual(observer.denied, testCase.expectedDenied) {
+				t.Fatalf("case %v: denied: expected: %v, got: %v", i+1, testCase.expectedDenied, observer.denied)
+			}
+
+			// Every policy is evaluated if there is no Deny, and the
+			// policies of the bucket evaluate both of their statements.
+			if testCase.expectedDenied == nil && len(observer.evaluated) != len(policies) {
+				t.Fatalf("case %v: evaluated: expected: %v, got: %v", i+1, len(policies), len(observer.evaluated))
+			}
+			if testCase.expectedAllowed >= 0 {
+				eval := observer.evaluated[testCase.expectedAllowed]
+				if eval.Decision != AllowDecision || eval.Statements != 2 {
+					t.Fatalf("case %v: expected: %v with 2 statements, got: %+v", i+1, AllowDecision, eval)
+				}
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	args := Args{Action: GetObjectAction, BucketName: "bucket1", ObjectName: "photo.jpg"}
+	if result, err := IsAllowedSerialContext(ctx, policies, args, nil); result || err != context.Canceled {
+		t.Fatalf("expected: %v, got: %v", context.Canceled, err)
+	}
+	if result, err := IsAllowedParContext(ctx, policies, args, nil); result || err != context.Canceled {
+		t.Fatalf("expected: %v, got: %v", context.Canceled, err)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 
 	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/pkg/v3/policy/condition"
 	"github.com/minio/pkg/v3/wildcard"
 )
 
@@ -34,6 +35,11 @@ func (p Principal) IsValid() bool {
 	return len(p.AWS) != 0
 }
 
+// IsZero - returns whether Principal is empty, which omits it in JSON.
+func (p Principal) IsZero() bool {
+	return len(p.AWS) == 0
+}
+
 // Equals - returns true if principals are equal.
 func (p Principal) Equals(pp Principal) bool {
 	return p.AWS.Equals(pp.AWS)
@@ -58,8 +64,14 @@ func (p Principal) MarshalJSON() ([]byte, error) {
 
 // Match - matches given principal is wildcard matching with Principal.
 func (p Principal) Match(principal string) bool {
+	return p.match(principal, nil)
+}
+
+// match - matches given principal with Principal, replacing supported policy
+// variables by their values in conditionValues first.
+func (p Principal) match(principal string, conditionValues map[string][]string) bool {
 	for _, pattern := range p.AWS.ToSlice() {
-		if wildcard.MatchSimple(pattern, principal) {
+		if wildcard.MatchSimple(condition.Substitute(pattern, conditionValues), principal) {
 			return true
 		}
 	}
@@ -67,6 +79,17 @@ func (p Principal) Match(principal string) bool {
 	return false
 }
 
+// validateVariables - returns an error if a principal contains a policy
+// variable which is not supported.
+func (p Principal) validateVariables() error {
+	for _, principal := range p.AWS.ToSlice() {
+		if err := condition.ValidateVariables(principal); err != nil {
+			return Errorf("%v in principal '%v'", err, principal)
+		}
+	}
+	return nil
+}
+
 // UnmarshalJSON - decodes JSON data to Principal.
 func (p *Principal) UnmarshalJSON(data []byte) error {
 	// subtype to avoid recursive call to UnmarshalJSON()
@@ -111,6 +111,29 @@ func TestPrincipalMatch(t *testing.T) {
 	}
 }
 
+func TestPrincipalMatchVariables(t *testing.T) {
+	conditionValues := map[string][]string{
+		"username": {"alice"},
+	}
+	testCases := []struct {
+		principals     Principal
+		principal      string
+		expectedResult bool
+	}{
+		{NewPrincipal("arn:aws:iam::*:user/${aws:username}"), "arn:aws:iam::AccountNumber:user/alice", true},
+		{NewPrincipal("arn:aws:iam::*:user/${aws:username}"), "arn:aws:iam::AccountNumber:user/bob", false},
+		{NewPrincipal("${ldap:user}"), "alice", false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.principals.match(testCase.principal, conditionValues)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestPrincipalUnmarshalJSON(t *testing.T) {
 	testCases := []struct {
 		data           []byte
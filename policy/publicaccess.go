@@ -0,0 +1,251 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/minio/pkg/v3/policy/condition"
+)
+
+// PublicAccessFinding - access granted to anyone by a bucket policy
+// statement.
+type PublicAccessFinding struct {
+	// Statement is the position of the statement in the bucket policy.
+	Statement int `json:"statement"`
+	SID       ID  `json:"sid,omitempty"`
+	// Actions lists the granted actions which are not denied to anyone
+	// by another statement.
+	Actions      []Action `json:"actions"`
+	Resources    []string `json:"resources,omitempty"`
+	NotResources []string `json:"notResources,omitempty"`
+	Read         bool     `json:"read"`
+	Write        bool     `json:"write"`
+	// Public is false if a condition restricts the access to known
+	// clients, i.e. Restrictions is not empty.
+	Public       bool                    `json:"public"`
+	Restrictions []condition.Description `json:"restrictions,omitempty"`
+	// Conditions lists the remaining conditions of the statement.
+	Conditions []condition.Description `json:"conditions,omitempty"`
+}
+
+// identityConditionKeys - condition keys identifying the requester, which
+// restrict access when matched against fixed values.
+var identityConditionKeys = []condition.KeyName{
+	condition.AWSUserID,
+	condition.AWSUsername,
+	condition.AWSPrincipalArn,
+	condition.AWSSourceArn,
+}
+
+// isRestricting - returns whether the condition limits access to a known
+// set of clients, i.e. source IP addresses in private ranges, requests made
+// over TLS or fixed requester identities.
+func isRestricting(d condition.Description) bool {
+	if len(d.Values) == 0 {
+		return false
+	}
+
+	switch {
+	case d.Name == "IpAddress" && strings.EqualFold(d.Key, string(condition.AWSSourceIP)):
+		for _, value := range d.Values {
+			if !isPrivateNetwork(value) {
+				return false
+			}
+		}
+		return true
+	case d.Name == "Bool" && strings.EqualFold(d.Key, string(condition.AWSSecureTransport)):
+		return len(d.Values) == 1 && strings.EqualFold(d.Values[0], "true")
+	}
+
+	switch d.Name {
+	case "StringEquals", "StringEqualsIgnoreCase", "StringLike", "ArnEquals", "ArnLike":
+	default:
+		return false
+	}
+	identity := false
+	for _, key := range identityConditionKeys {
+		if strings.EqualFold(d.Key, string(key)) {
+			identity = true
+			break
+		}
+	}
+	if !identity {
+		return false
+	}
+	for _, value := range d.Values {
+		if strings.ContainsAny(value, "*?$") {
+			return false
+		}
+	}
+	return true
+}
+
+// isPrivateNetwork - returns whether the given CIDR only contains private
+// or loopback addresses.
+func isPrivateNetwork(cidr string) bool {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		ip := net.ParseIP(cidr)
+		if ip == nil {
+			return false
+		}
+		return ip.IsPrivate() || ip.IsLoopback()
+	}
+
+	first := ipNet.IP
+	last := make(net.IP, len(first))
+	for i := range first {
+		last[i] = first[i] | ^ipNet.Mask[i]
+	}
+	// Private ranges are aligned, so a network is private if both its
+	// first and last address are.
+	for _, ip := range []net.IP{first, last} {
+		if !ip.IsPrivate() && !ip.IsLoopback() {
+			return false
+		}
+	}
+	return true
+}
+
+// isReadAction - returns whether the action only reads data or metadata.
+func isReadAction(action Action) bool {
+	name := strings.TrimPrefix(string(action), "s3:")
+	return strings.HasPrefix(name, "Get") || strings.HasPrefix(name, "List")
+}
+
+// grantedActions - returns all supported actions matched by the statement.
+func (statement BPStatement) grantedActions() []Action {
+	var actions []Action
+	for action := range supportedActions {
+		if (statement.Actions.IsEmpty() || statement.Actions.Match(action)) &&
+			!statement.NotActions.Match(action) {
+			actions = append(actions, action)
+		}
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i] < actions[j] })
+	return actions
+}
+
+// isPublic - returns whether the statement applies to any account.
+func (statement BPStatement) isPublic() bool {
+	if statement.NotPrincipal.IsValid() {
+		return !statement.NotPrincipal.AWS.Contains("*")
+	}
+	return statement.Principal.AWS.Contains("*")
+}
+
+// deniesToAnyone - returns whether the statement denies the action on all
+// given resources to anyone, regardless of conditions.
+func (statement BPStatement) deniesToAnyone(action Action, resources ResourceSet) bool {
+	if statement.Effect != Deny || !statement.isPublic() || len(statement.Conditions) > 0 ||
+		len(statement.NotResources) > 0 || len(resources) == 0 {
+		return false
+	}
+	if (!statement.Actions.IsEmpty() && !statement.Actions.Match(action)) || statement.NotActions.Match(action) {
+		return false
+	}
+	for resource := range resources {
+		covered := false
+		for pattern := range statement.Resources {
+			if resourceCovers(pattern, resource) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// AnalyzePublicAccess - returns a finding for every Allow statement granting
+// access to the '*' principal, with the actions not denied to anyone by a
+// Deny statement without conditions. Conditions on the source IP limited to
+// private networks, on secure transport and on fixed requester identities
+// are reported as restrictions, which make the access not public, the
+// remaining conditions are reported as is.
+func (policy BucketPolicy) AnalyzePublicAccess() []PublicAccessFinding {
+	var findings []PublicAccessFinding
+	for i, statement := range policy.Statements {
+		if statement.Effect != Allow || !statement.isPublic() {
+			continue
+		}
+
+		finding := PublicAccessFinding{
+			Statement: i,
+			SID:       statement.SID,
+		}
+		for _, action := range statement.grantedActions() {
+			denied := false
+			for _, st := range policy.Statements {
+				if st.deniesToAnyone(action, statement.Resources) {
+					denied = true
+					break
+				}
+			}
+			if denied {
+				continue
+			}
+			finding.Actions = append(finding.Actions, action)
+			if isReadAction(action) {
+				finding.Read = true
+			} else {
+				finding.Write = true
+			}
+		}
+		if len(finding.Actions) == 0 {
+			continue
+		}
+
+		for _, resource := range statement.Resources.ToSlice() {
+			finding.Resources = append(finding.Resources, resource.String())
+		}
+		sort.Strings(finding.Resources)
+		for _, resource := range statement.NotResources.ToSlice() {
+			finding.NotResources = append(finding.NotResources, resource.String())
+		}
+		sort.Strings(finding.NotResources)
+
+		for _, d := range statement.Conditions.Describe() {
+			if isRestricting(d) {
+				finding.Restrictions = append(finding.Restrictions, d)
+			} else {
+				finding.Conditions = append(finding.Conditions, d)
+			}
+		}
+		finding.Public = len(finding.Restrictions) == 0
+
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// IsPublic - returns whether the bucket policy grants any access to anyone
+// without restricting conditions.
+func (policy BucketPolicy) IsPublic() bool {
+	for _, finding := range policy.AnalyzePublicAccess() {
+		if finding.Public {
+			return true
+		}
+	}
+	return false
+}
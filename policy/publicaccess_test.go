@@ -0,0 +1,109 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBucketPolicyAnalyzePublicAccess(t *testing.T) {
+	data := `{"Version": "2012-10-17", "Statement": [
+    {"Effect": "Allow", "Principal": "*", "Action": ["s3:GetObject", "s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/public/*"]},
+    {"Effect": "Allow", "Principal": {"AWS": ["Q3AM3UQ867SPQQA43P2F"]}, "Action": ["s3:*"], "Resource": ["arn:aws:s3:::mybucket/*"]},
+    {"Effect": "Allow", "Principal": "*", "Action": ["s3:ListBucket"], "Resource": ["arn:aws:s3:::mybucket"],
+     "Condition": {"IpAddress": {"aws:SourceIp": ["10.0.0.0/8", "192.168.1.0/24"]}, "StringLike": {"s3:prefix": "public/*"}}},
+    {"Effect": "Allow", "Principal": "*", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/shared/*"],
+     "Condition": {"IpAddress": {"aws:SourceIp": ["10.0.0.0/8", "8.8.8.0/24"]}}},
+    {"Effect": "Allow", "Principal": "*", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/tls/*"],
+     "Condition": {"Bool": {"aws:SecureTransport": "true"}}},
+    {"Effect": "Allow", "Principal": "*", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::mybucket/public/*"]},
+    {"Effect": "Deny", "Principal": "*", "Action": ["s3:Delete*", "s3:PutObject"], "Resource": ["arn:aws:s3:::mybucket/*"]}
+]}`
+	policy, err := ParseBucketPolicyConfig(strings.NewReader(data), "mybucket")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	findings := policy.AnalyzePublicAccess()
+
+	testCases := []struct {
+		statement       int
+		actions         []Action
+		read            bool
+		write           bool
+		public          bool
+		numRestrictions int
+		numConditions   int
+	}{
+		{0, []Action{GetObjectAction}, true, false, true, 0, 0},
+		{2, []Action{ListBucketAction}, true, false, false, 1, 1},
+		{3, []Action{GetObjectAction}, true, false, true, 0, 1},
+		{4, []Action{GetObjectAction}, true, false, false, 1, 0},
+	}
+
+	if len(findings) != len(testCases) {
+		t.Fatalf("expected: %v findings, got: %+v", len(testCases), findings)
+	}
+
+	for i, testCase := range testCases {
+		finding := findings[i]
+		if finding.Statement != testCase.statement {
+			t.Fatalf("case %v: statement: expected: %v, got: %v", i+1, testCase.statement, finding.Statement)
+		}
+		if !reflect.DeepEqual(finding.Actions, testCase.actions) {
+			t.Fatalf("case %v: actions: expected: %v, got: %v", i+1, testCase.actions, finding.Actions)
+		}
+		if finding.Read != testCase.read || finding.Write != testCase.write || finding.Public != testCase.public {
+			t.Fatalf("case %v: unexpected finding %+v", i+1, finding)
+		}
+		if len(finding.Restrictions) != testCase.numRestrictions || len(finding.Conditions) != testCase.numConditions {
+			t.Fatalf("case %v: unexpected conditions %+v", i+1, finding)
+		}
+	}
+
+	if !policy.IsPublic() {
+		t.Fatalf("expected policy to be public")
+	}
+}
+
+func TestIsPrivateNetwork(t *testing.T) {
+	testCases := []struct {
+		cidr           string
+		expectedResult bool
+	}{
+		{"10.0.0.0/8", true},
+		{"10.1.2.0/24", true},
+		{"172.16.0.0/12", true},
+		{"172.16.0.0/11", false},
+		{"192.168.1.10", true},
+		{"127.0.0.1/32", true},
+		{"0.0.0.0/0", false},
+		{"8.8.8.8/32", false},
+		{"fd00::/8", true},
+		{"2001:db8::/32", false},
+		{"invalid", false},
+	}
+
+	for i, testCase := range testCases {
+		if result := isPrivateNetwork(testCase.cidr); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
@@ -18,7 +18,6 @@
 package policy
 
 import (
-	"bytes"
 	"encoding/json"
 	"path"
 	"strings"
@@ -130,59 +129,19 @@ func (r Resource) MatchResource(resource string) bool {
 
 // Match - matches object name with resource pattern, including specific conditionals.
 func (r Resource) Match(resource string, conditionValues map[string][]string) bool {
-	// Happy path, with no replacements
-	idx := strings.IndexByte(r.Pattern, '$')
-	if idx < 0 {
-		if cp := path.Clean(resource); cp != "." && cp == r.Pattern {
-			return true
-		}
-		return wildcard.Match(r.Pattern, resource)
-	}
-
-	// Use a small buffer
-	pat := smallBufPool.Get().(*bytes.Buffer)
-	defer smallBufPool.Put(pat)
-	pat.Reset()
-
-	// Do replacement of known keys.
-	pat.WriteString(r.Pattern[:idx])
-	remain := r.Pattern[idx:]
-	for len(remain) > 0 {
-		val := remain[0]
-		if val != '$' || len(remain) < 3 {
-			pat.WriteByte(val)
-			remain = remain[1:]
-			continue
-		}
-		keyEnds := strings.IndexByte(remain, '}')
-
-		// If no curly brackets, emit as-is.
-		if remain[1] != '{' || keyEnds < 0 {
-			pat.WriteByte('$')
-			remain = remain[1:]
-			continue
-		}
-
-		ckey := condition.KeyName(remain[2:keyEnds])
-
-		// Only replace keys we know
-		if rvalues, ok := conditionValues[ckey.Name()]; condition.CommonKeysMap[ckey] && ok && rvalues[0] != "" {
-			pat.WriteString(rvalues[0])
-		} else {
-			// Write without replacing...
-			pat.WriteString("${")
-			pat.WriteString(string(ckey))
-			pat.WriteString("}")
-		}
-		remain = remain[keyEnds+1:]
-	}
-	pattern := pat.String()
+	pattern := r.substitute(conditionValues)
 	if cp := path.Clean(resource); cp != "." && cp == pattern {
 		return true
 	}
 	return wildcard.Match(pattern, resource)
 }
 
+// substitute - returns the resource pattern with supported policy variables
+// replaced by their values in conditionValues, see condition.Substitute().
+func (r Resource) substitute(conditionValues map[string][]string) string {
+	return condition.Substitute(r.Pattern, conditionValues)
+}
+
 // MarshalJSON - encodes Resource to JSON data.
 func (r Resource) MarshalJSON() ([]byte, error) {
 	if !r.IsValid() {
@@ -218,6 +177,10 @@ func (r Resource) Validate() error {
 	if !r.IsValid() {
 		return Errorf("invalid resource")
 	}
+
+	if err := condition.ValidateVariables(r.Pattern); err != nil {
+		return Errorf("%v in resource %v", err, r)
+	}
 	return nil
 }
 
@@ -227,6 +190,10 @@ func (r Resource) ValidateBucket(bucketName string) error {
 		return Errorf("invalid resource")
 	}
 
+	if err := condition.ValidateVariables(r.Pattern); err != nil {
+		return Errorf("%v in resource %v", err, r)
+	}
+
 	// For the resource to match the bucket, there are two cases:
 	//
 	//   1. the whole resource pattern must match the bucket name (e.g.
@@ -147,6 +147,36 @@ func TestResourceMatch(t *testing.T) {
 	}
 }
 
+func TestResourceMatchVariables(t *testing.T) {
+	conditionValues := map[string][]string{
+		"username":          {"alice"},
+		"PrincipalTag/team": {"blue"},
+		"sub":               {""},
+	}
+	testCases := []struct {
+		resource       Resource
+		objectName     string
+		expectedResult bool
+	}{
+		{NewResource("mybucket/${aws:username}/*"), "mybucket/alice/myobject", true},
+		{NewResource("mybucket/${aws:username}/*"), "mybucket/bob/myobject", false},
+		{NewResource("mybucket/${aws:PrincipalTag/team}/*"), "mybucket/blue/myobject", true},
+		{NewResource("mybucket/${aws:PrincipalTag/team}/*"), "mybucket/red/myobject", false},
+		// Empty values are not substituted.
+		{NewResource("mybucket/${jwt:sub}/*"), "mybucket//myobject", false},
+		{NewResource("mybucket/${jwt:sub}/*"), "mybucket/${jwt:sub}/myobject", true},
+		{NewResource("mybucket/$alice/*"), "mybucket/$alice/myobject", true},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.resource.Match(testCase.objectName, conditionValues)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestResourceMarshalJSON(t *testing.T) {
 	// Only test with valid resources (specifically, resources must not start
 	// with '/')
@@ -225,6 +255,10 @@ func TestResourceValidate(t *testing.T) {
 		{NewResource("mybucket/myobject*"), false},
 		{NewResource("/myobject*"), true},
 		{NewResource("/"), true},
+		{NewResource("mybucket/${aws:username}/*"), false},
+		{NewResource("mybucket/${aws:PrincipalTag/team}/*"), false},
+		{NewResource("mybucket/${aws:unknown}/*"), true},
+		{NewResource("mybucket/${aws:PrincipalTag}/*"), true},
 	}
 
 	for i, testCase := range testCases {
@@ -252,6 +286,8 @@ func TestResourceValidateBucket(t *testing.T) {
 		// corner cases for the given patterns and buckets.
 		{NewResource("mybucket*a/myobject*"), "mybucket", false},
 		{NewResource("mybucket*a/myobject*"), "mybucket22", false},
+		{NewResource("mybucket/${jwt:sub}/*"), "mybucket", false},
+		{NewResource("mybucket/${jwt:unknown}/*"), "mybucket", true},
 	}
 
 	for i, testCase := range testCases {
@@ -91,6 +91,7 @@ func (resourceSet ResourceSet) MarshalJSON() ([]byte, error) {
 	for resource := range resourceSet {
 		resources = append(resources, resource)
 	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].String() < resources[j].String() })
 
 	return json.Marshal(resources)
 }
@@ -0,0 +1,229 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SimulationCase - a request to simulate along with its expected decision.
+type SimulationCase struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Policies lists the names of the policies attached to the request,
+	// all policies of the simulator are used when empty.
+	Policies   []string            `json:"policies,omitempty" yaml:"policies,omitempty"`
+	Account    string              `json:"account,omitempty" yaml:"account,omitempty"`
+	Groups     []string            `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Action     Action              `json:"action" yaml:"action"`
+	Bucket     string              `json:"bucket,omitempty" yaml:"bucket,omitempty"`
+	Object     string              `json:"object,omitempty" yaml:"object,omitempty"`
+	Conditions map[string][]string `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	IsOwner    bool                `json:"owner,omitempty" yaml:"owner,omitempty"`
+	DenyOnly   bool                `json:"denyOnly,omitempty" yaml:"denyOnly,omitempty"`
+	Expect     Decision            `json:"expect" yaml:"expect"`
+}
+
+// Args - returns policy args of the simulation case.
+func (c SimulationCase) Args() Args {
+	return Args{
+		AccountName:     c.Account,
+		Groups:          c.Groups,
+		Action:          c.Action,
+		BucketName:      c.Bucket,
+		ObjectName:      c.Object,
+		ConditionValues: c.Conditions,
+		IsOwner:         c.IsOwner,
+		DenyOnly:        c.DenyOnly,
+	}
+}
+
+// ParseSimulationCases - parses a YAML or JSON list of simulation cases from
+// given reader. Every case must have an action and an expected decision.
+func ParseSimulationCases(reader io.Reader) ([]SimulationCase, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, Errorf("%w", err)
+	}
+
+	var cases []SimulationCase
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(&cases); err != nil && !errors.Is(err, io.EOF) {
+		return nil, Errorf("%w", err)
+	}
+
+	// A case without expect would silently expect NoDecision, so the
+	// presence of the field is checked on the raw cases.
+	var fields []map[string]any
+	if err = yaml.Unmarshal(data, &fields); err != nil {
+		return nil, Errorf("%w", err)
+	}
+
+	for i, c := range cases {
+		if c.Action == "" {
+			return nil, Errorf("simulation case %v: action must not be empty", i+1)
+		}
+		if expect, ok := fields[i]["expect"]; !ok || expect == nil {
+			return nil, Errorf("simulation case %v: expect must be set", i+1)
+		}
+	}
+
+	return cases, nil
+}
+
+// SimulationResult - outcome of a single simulation case.
+type SimulationResult struct {
+	Case SimulationCase `json:"case"`
+	// Decision is the combined decision of all policies of the case.
+	Decision Decision `json:"decision"`
+	// PolicyDecisions holds the decision of each evaluated policy.
+	PolicyDecisions map[string]Decision `json:"policyDecisions"`
+	Passed          bool                `json:"passed"`
+}
+
+// SimulationReport - outcome of all simulation cases.
+type SimulationReport struct {
+	Policies []string           `json:"policies"`
+	Results  []SimulationResult `json:"results"`
+}
+
+// Passed - returns whether all simulation cases got their expected decision.
+func (r SimulationReport) Passed() bool {
+	for _, result := range r.Results {
+		if !result.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Mismatches - returns results of cases which did not get their expected
+// decision.
+func (r SimulationReport) Mismatches() []SimulationResult {
+	var mismatches []SimulationResult
+	for _, result := range r.Results {
+		if !result.Passed {
+			mismatches = append(mismatches, result)
+		}
+	}
+	return mismatches
+}
+
+// Matrix - returns the decision matrix of the report, with one row per case
+// and one column per policy followed by the combined, expected decision and
+// the outcome. The first row holds the column headers. Policies not
+// attached to a case are left empty.
+func (r SimulationReport) Matrix() [][]string {
+	header := []string{"Case"}
+	header = append(header, r.Policies...)
+	header = append(header, "Decision", "Expected", "Result")
+
+	rows := [][]string{header}
+	for i, result := range r.Results {
+		name := result.Case.Name
+		if name == "" {
+			name = strconv.Itoa(i + 1)
+		}
+		row := []string{name}
+		for _, policy := range r.Policies {
+			if decision, ok := result.PolicyDecisions[policy]; ok {
+				row = append(row, decision.String())
+			} else {
+				row = append(row, "")
+			}
+		}
+		outcome := "PASS"
+		if !result.Passed {
+			outcome = "FAIL"
+		}
+		row = append(row, result.Decision.String(), result.Case.Expect.String(), outcome)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// Simulator - evaluates simulation cases against a set of named policies.
+type Simulator struct {
+	policies map[string]Policy
+	names    []string
+}
+
+// NewSimulator - creates new simulator for the given named policies.
+func NewSimulator(policies map[string]Policy) *Simulator {
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &Simulator{
+		policies: policies,
+		names:    names,
+	}
+}
+
+// Simulate - evaluates each case through Policy.Decide() and reports its
+// outcome. The decisions of the attached policies are combined in the same
+// way as IsAllowedSerial(). An error is returned if a case refers to an
+// unknown policy.
+func (s *Simulator) Simulate(cases []SimulationCase) (SimulationReport, error) {
+	report := SimulationReport{
+		Policies: s.names,
+		Results:  make([]SimulationResult, 0, len(cases)),
+	}
+
+	for i, c := range cases {
+		names := c.Policies
+		if len(names) == 0 {
+			names = s.names
+		}
+
+		args := c.Args()
+		result := SimulationResult{
+			Case:            c,
+			Decision:        NoDecision,
+			PolicyDecisions: make(map[string]Decision, len(names)),
+		}
+		for _, name := range names {
+			policy, ok := s.policies[name]
+			if !ok {
+				return report, Errorf("simulation case %v: unknown policy '%v'", i+1, name)
+			}
+
+			decision := policy.Decide(&args)
+			result.PolicyDecisions[name] = decision
+			switch {
+			case decision == DenyDecision:
+				result.Decision = DenyDecision
+			case decision == AllowDecision && result.Decision == NoDecision:
+				result.Decision = AllowDecision
+			}
+		}
+
+		result.Passed = result.Decision == c.Expect
+		report.Results = append(report.Results, result)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package policy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSimulationCases(t *testing.T) {
+	yamlData := `
+- name: read own bucket
+  policies: [mybucket-read]
+  account: alice
+  groups: [dev]
+  action: s3:GetObject
+  bucket: mybucket
+  object: myobject
+  conditions:
+    SourceIp: [192.168.1.10]
+  expect: Allow
+- action: s3:PutObject
+  bucket: mybucket
+  expect: deny
+`
+	jsonData := `[{"name": "read own bucket", "policies": ["mybucket-read"], "account": "alice", "groups": ["dev"],
+"action": "s3:GetObject", "bucket": "mybucket", "object": "myobject", "conditions": {"SourceIp": ["192.168.1.10"]},
+"expect": "Allow"}, {"action": "s3:PutObject", "bucket": "mybucket", "expect": "Deny"}]`
+
+	expectedResult := []SimulationCase{
+		{
+			Name:       "read own bucket",
+			Policies:   []string{"mybucket-read"},
+			Account:    "alice",
+			Groups:     []string{"dev"},
+			Action:     GetObjectAction,
+			Bucket:     "mybucket",
+			Object:     "myobject",
+			Conditions: map[string][]string{"SourceIp": {"192.168.1.10"}},
+			Expect:     AllowDecision,
+		},
+		{
+			Action: PutObjectAction,
+			Bucket: "mybucket",
+			Expect: DenyDecision,
+		},
+	}
+
+	testCases := []struct {
+		data           string
+		expectedResult []SimulationCase
+		expectErr      bool
+	}{
+		{yamlData, expectedResult, false},
+		{jsonData, expectedResult, false},
+		{"", nil, false},
+		// unknown field.
+		{"- action: s3:GetObject\n  bucket: mybucket\n  expected: Allow\n", nil, true},
+		// invalid decision.
+		{"- action: s3:GetObject\n  expect: Maybe\n", nil, true},
+		// missing action.
+		{"- bucket: mybucket\n  expect: Allow\n", nil, true},
+		// empty expect.
+		{"- action: s3:GetObject\n  expect: \"\"\n", nil, true},
+		{`[{"action": "s3:GetObject", "expect": ""}]`, nil, true},
+		{"- action: s3:GetObject\n  expect:\n", nil, true},
+		// missing expect.
+		{"- action: s3:GetObject\n  bucket: mybucket\n", nil, true},
+		{`[{"action": "s3:GetObject", "expect": "Allow"}, {"action": "s3:PutObject"}]`, nil, true},
+	}
+
+	for i, testCase := range testCases {
+		result, err := ParseSimulationCases(strings.NewReader(testCase.data))
+		expectErr := (err != nil)
+
+		if testCase.expectErr != expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v, %v", i+1, testCase.expectErr, expectErr, err)
+		}
+
+		if !testCase.expectErr && !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: result: expected: %+v, got: %+v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestSimulatorSimulate(t *testing.T) {
+	denyData := `{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Deny",
+            "Action": ["s3:PutObject", "s3:DeleteObject"],
+            "Resource": ["arn:aws:s3:::archive/*"]
+        }
+    ]
+}`
+	deny, err := ParseConfig(strings.NewReader(denyData))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	simulator := NewSimulator(map[string]Policy{
+		"readwrite":  DefaultPolicies[0].Definition,
+		"readonly":   DefaultPolicies[1].Definition,
+		"archive-ro": *deny,
+	})
+
+	cases := []SimulationCase{
+		{Name: "rw-put", Policies: []string{"readwrite"}, Action: PutObjectAction, Bucket: "archive", Object: "a", Expect: AllowDecision},
+		{Name: "rw-archive-put", Policies: []string{"readwrite", "archive-ro"}, Action: PutObjectAction, Bucket: "archive", Object: "a", Expect: DenyDecision},
+		{Name: "ro-put", Policies: []string{"readonly"}, Action: PutObjectAction, Bucket: "archive", Object: "a", Expect: NoDecision},
+		{Name: "all-get", Action: GetObjectAction, Bucket: "archive", Object: "a", Expect: AllowDecision},
+		// deliberately mismatching expectation.
+		{Name: "ro-delete", Policies: []string{"readonly"}, Action: DeleteObjectAction, Bucket: "data", Object: "a", Expect: AllowDecision},
+	}
+
+	report, err := simulator.Simulate(cases)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	if report.Passed() {
+		t.Fatalf("expected report to fail")
+	}
+
+	mismatches := report.Mismatches()
+	if len(mismatches) != 1 || mismatches[0].Case.Name != "ro-delete" || mismatches[0].Decision != NoDecision {
+		t.Fatalf("unexpected mismatches %+v", mismatches)
+	}
+
+	expectedMatrix := [][]string{
+		{"Case", "archive-ro", "readonly", "readwrite", "Decision", "Expected", "Result"},
+		{"rw-put", "", "", "Allow", "Allow", "Allow", "PASS"},
+		{"rw-archive-put", "Deny", "", "Allow", "Deny", "Deny", "PASS"},
+		{"ro-put", "", "NoDecision", "", "NoDecision", "NoDecision", "PASS"},
+		{"all-get", "NoDecision", "Allow", "Allow", "Allow", "Allow", "PASS"},
+		{"ro-delete", "", "NoDecision", "", "NoDecision", "Allow", "FAIL"},
+	}
+	if matrix := report.Matrix(); !reflect.DeepEqual(matrix, expectedMatrix) {
+		t.Fatalf("matrix: expected: %v, got: %v", expectedMatrix, matrix)
+	}
+
+	for i, result := range report.Results {
+		var policies []Policy
+		for name := range result.PolicyDecisions {
+			policies = append(policies, simulator.policies[name])
+		}
+		if IsAllowedSerial(policies, cases[i].Args()) != (result.Decision == AllowDecision) {
+			t.Fatalf("case %v: simulation does not agree with IsAllowedSerial()", i+1)
+		}
+	}
+
+	if _, err = simulator.Simulate([]SimulationCase{{Policies: []string{"missing"}, Action: GetObjectAction}}); err == nil {
+		t.Fatalf("expected error for unknown policy")
+	}
+}
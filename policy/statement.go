@@ -43,6 +43,18 @@ var smallBufPool = sync.Pool{
 	New: func() interface{} { return &bytes.Buffer{} },
 }
 
+// resourceName - returns the resource which statements match for given
+// bucket and object name, same as the one built in IsAllowedPtr().
+func resourceName(bucketName, objectName string) string {
+	if objectName == "" {
+		return bucketName + "/"
+	}
+	if strings.HasPrefix(objectName, "/") {
+		return bucketName + objectName
+	}
+	return bucketName + "/" + objectName
+}
+
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (statement Statement) IsAllowed(args Args) bool {
 	return statement.IsAllowedPtr(&args)
@@ -126,10 +138,11 @@ func (statement Statement) isKMS() bool {
 	return false
 }
 
-// isValid - checks whether statement is valid or not.
+// isValid - checks whether statement is valid or not. The error names the
+// field at fault, see errorField(), unless no single field is to blame.
 func (statement Statement) isValid() error {
 	if !statement.Effect.IsValid() {
-		return Errorf("invalid Effect %v", statement.Effect)
+		return fieldErrorf("Effect", "invalid Effect %v", statement.Effect)
 	}
 
 	if len(statement.Actions) == 0 && len(statement.NotActions) == 0 {
@@ -137,18 +150,22 @@ func (statement Statement) isValid() error {
 	}
 
 	if len(statement.Actions) > 0 && len(statement.NotActions) > 0 {
-		return Errorf("Action and NotAction cannot be specified in the same statement")
+		return fieldErrorf("NotAction", "Action and NotAction cannot be specified in the same statement")
+	}
+
+	if err := statement.Conditions.ValidateVariables(); err != nil {
+		return fieldErrorf("Condition", "%w", err)
 	}
 
 	if statement.isAdmin() {
 		if err := statement.Actions.ValidateAdmin(); err != nil {
-			return err
+			return fieldErrorf("Action", "%w", err)
 		}
 		for action := range statement.Actions {
 			keys := statement.Conditions.Keys()
 			keyDiff := keys.Difference(adminActionConditionKeyMap[action])
 			if !keyDiff.IsEmpty() {
-				return Errorf("unsupported condition keys '%v' used for action '%v'", keyDiff, action)
+				return fieldErrorf("Condition", "unsupported condition keys '%v' used for action '%v'", keyDiff, action)
 			}
 		}
 		return nil
@@ -156,13 +173,13 @@ func (statement Statement) isValid() error {
 
 	if statement.isSTS() {
 		if err := statement.Actions.ValidateSTS(); err != nil {
-			return err
+			return fieldErrorf("Action", "%w", err)
 		}
 		for action := range statement.Actions {
 			keys := statement.Conditions.Keys()
 			keyDiff := keys.Difference(stsActionConditionKeyMap[action])
 			if !keyDiff.IsEmpty() {
-				return Errorf("unsupported condition keys '%v' used for action '%v'", keyDiff, action)
+				return fieldErrorf("Condition", "unsupported condition keys '%v' used for action '%v'", keyDiff, action)
 			}
 		}
 		return nil
@@ -170,19 +187,19 @@ func (statement Statement) isValid() error {
 
 	if statement.isKMS() {
 		if err := statement.Actions.ValidateKMS(); err != nil {
-			return err
+			return fieldErrorf("Action", "%w", err)
 		}
 		if err := statement.Resources.ValidateKMS(); err != nil {
-			return err
+			return fieldErrorf("Resource", "%w", err)
 		}
 		if err := statement.NotResources.ValidateKMS(); err != nil {
-			return err
+			return fieldErrorf("NotResource", "%w", err)
 		}
 		return nil
 	}
 
 	if !statement.SID.IsValid() {
-		return Errorf("invalid SID %v", statement.SID)
+		return fieldErrorf("Sid", "invalid SID %v", statement.SID)
 	}
 
 	if len(statement.Resources) == 0 && len(statement.NotResources) == 0 {
@@ -190,33 +207,33 @@ func (statement Statement) isValid() error {
 	}
 
 	if len(statement.Resources) > 0 && len(statement.NotResources) > 0 {
-		return Errorf("Resource and NotResource cannot be specified in the same statement")
+		return fieldErrorf("NotResource", "Resource and NotResource cannot be specified in the same statement")
 	}
 
 	if err := statement.Resources.ValidateS3(); err != nil {
-		return err
+		return fieldErrorf("Resource", "%w", err)
 	}
 
 	if err := statement.NotResources.ValidateS3(); err != nil {
-		return err
+		return fieldErrorf("NotResource", "%w", err)
 	}
 
 	if err := statement.Actions.Validate(); err != nil {
-		return err
+		return fieldErrorf("Action", "%w", err)
 	}
 
 	for action := range statement.Actions {
 		if len(statement.Resources) > 0 && !statement.Resources.ObjectResourceExists() && !statement.Resources.BucketResourceExists() {
-			return Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
+			return fieldErrorf("Resource", "unsupported Resource found %v for action %v", statement.Resources, action)
 		}
 		if len(statement.NotResources) > 0 && !statement.NotResources.ObjectResourceExists() && !statement.NotResources.BucketResourceExists() {
-			return Errorf("unsupported NotResource found %v for action %v", statement.NotResources, action)
+			return fieldErrorf("NotResource", "unsupported NotResource found %v for action %v", statement.NotResources, action)
 		}
 
 		keys := statement.Conditions.Keys()
 		keyDiff := keys.Difference(IAMActionConditionKeyMap.Lookup(action))
 		if !keyDiff.IsEmpty() {
-			return Errorf("unsupported condition keys '%v' used for action '%v'", keyDiff, action)
+			return fieldErrorf("Condition", "unsupported condition keys '%v' used for action '%v'", keyDiff, action)
 		}
 	}
 
@@ -591,3 +591,38 @@ func TestStatementValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestStatementValidateVariables(t *testing.T) {
+	testCases := []struct {
+		data      string
+		expectErr bool
+	}{
+		{`{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/${aws:username}/*"}`, false},
+		{`{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/${aws:userName}/*"}`, true},
+		{`{"Effect": "Allow", "Action": "s3:GetObject", "NotResource": "arn:aws:s3:::mybucket/${jwt:unknown}/*"}`, true},
+		{`{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*",
+		   "Condition": {"StringEquals": {"ldap:user": "${aws:PrincipalTag/team}"}}}`, false},
+		{`{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*",
+		   "Condition": {"StringEquals": {"aws:username": "${ldap:unknown}"}}}`, true},
+		{`{"Effect": "Allow", "Action": "admin:ServerInfo",
+		   "Condition": {"StringLike": {"aws:username": "${aws:PrincipalTag}"}}}`, true},
+		{`{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::mybucket/*",
+		   "Condition": {"IpAddress": {"aws:SourceIp": ["10.0.0.0/8", "${jwt:address}"]}}}`, false},
+	}
+
+	for i, testCase := range testCases {
+		var statement Statement
+		if err := json.Unmarshal([]byte(testCase.data), &statement); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		err := statement.Validate()
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if expectErr && errorField(err) == "" {
+			t.Fatalf("case %v: expected invalid field", i+1)
+		}
+	}
+}
@@ -79,3 +79,24 @@ func FormatJSONSyntaxError(data io.Reader, offset int64) (highlight string) {
 
 	return fmt.Sprintf(errorFmt, errLine, readLine.String()[idx:])
 }
+
+// JSONPosition returns the line and column, both starting at 1, of the byte
+// at the given offset in data, e.g. to report the location of an invalid
+// value in a JSON document.
+func JSONPosition(data io.Reader, offset int64) (line, column int) {
+	line, column = 1, 1
+	bio := bufio.NewReader(data)
+	for i := int64(0); i < offset; i++ {
+		b, err := bio.ReadByte()
+		if err != nil {
+			break
+		}
+		if b == '\n' {
+			line++
+			column = 1
+			continue
+		}
+		column++
+	}
+	return line, column
+}
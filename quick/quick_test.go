@@ -502,3 +502,25 @@ func TestDeepDiff(t *testing.T) {
 	//		fmt.Printf("DeepDiff[%d]: %s=%v\n", i, field.Name(), field.Value())
 	//	}
 }
+
+func TestJSONPosition(t *testing.T) {
+	data := "{\n  \"a\": 1,\n\t\"b\": 2\n}"
+	testCases := []struct {
+		offset         int64
+		expectedLine   int
+		expectedColumn int
+	}{
+		{0, 1, 1},
+		{1, 1, 2},
+		{4, 2, 3},
+		{13, 3, 2},
+		{int64(len(data)) + 10, 4, 2},
+	}
+
+	for i, testCase := range testCases {
+		line, column := JSONPosition(strings.NewReader(data), testCase.offset)
+		if line != testCase.expectedLine || column != testCase.expectedColumn {
+			t.Fatalf("case %v: expected: %v:%v, got: %v:%v", i+1, testCase.expectedLine, testCase.expectedColumn, line, column)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package wildcard
+
+import "errors"
+
+// The functions below reason about the sets of names matched by patterns.
+// A pattern is read as an automaton whose states are its positions, state
+// len(pattern) meaning the whole pattern is matched. Both patterns are run
+// on the same names at once, trying every byte they mention and one byte
+// standing for all the others, which '*' and '?' match alike.
+//
+// The sets of states reached by the same names can grow exponentially with
+// the number of '?' following a '*', e.g. for '*a????????b'. The search is
+// therefore limited to maxSearchStates sets of states, so that it takes at
+// most time proportional to maxSearchStates times the number of distinct
+// bytes times the length of the patterns, and ErrTooComplex is returned
+// beyond that.
+
+// maxSearchStates - maximum number of sets of states visited by a search.
+const maxSearchStates = 10000
+
+// ErrTooComplex - the patterns need too many states to be analyzed.
+var ErrTooComplex = errors.New("wildcard: patterns are too complex to analyze")
+
+// IsSubset - returns whether every name matched by pattern a is also matched
+// by pattern b, both matched like Match(). Otherwise the shortest name matched
+// by a but not by b is returned as witness. ErrTooComplex is returned if the
+// patterns exceed the search limit.
+func IsSubset(a, b string) (subset bool, witness string, err error) {
+	if a == b {
+		return true, "", nil
+	}
+	if !Has(a) {
+		if Match(b, a) {
+			return true, "", nil
+		}
+		return false, a, nil
+	}
+
+	witness, found, err := search(a, b, func(matchedA, matchedB bool) bool {
+		return matchedA && !matchedB
+	})
+	if err != nil {
+		return false, "", err
+	}
+	return !found, witness, nil
+}
+
+// Intersects - returns whether some name is matched by both patterns a and b,
+// matched like Match(), and the shortest such name as witness. ErrTooComplex
+// is returned if the patterns exceed the search limit.
+func Intersects(a, b string) (intersects bool, witness string, err error) {
+	for _, name := range []string{a, b} {
+		if !Has(name) {
+			if Match(a, name) && Match(b, name) {
+				return true, name, nil
+			}
+			return false, "", nil
+		}
+	}
+
+	witness, found, err := search(a, b, func(matchedA, matchedB bool) bool {
+		return matchedA && matchedB
+	})
+	if err != nil {
+		return false, "", err
+	}
+	return found, witness, nil
+}
+
+// search - returns the shortest name for which found() returns true, given
+// whether patterns a and b match it.
+func search(a, b string, found func(matchedA, matchedB bool) bool) (string, bool, error) {
+	type node struct {
+		a, b   []bool
+		parent int
+		c      byte
+	}
+
+	bytes := alphabet(a, b)
+	nodes := []node{{a: startStates(a), b: startStates(b), parent: -1}}
+	seen := map[string]bool{statesKey(nodes[0].a, nodes[0].b): true}
+	for i := 0; i < len(nodes); i++ {
+		n := nodes[i]
+		if found(n.a[len(a)], n.b[len(b)]) {
+			var name []byte
+			for ; nodes[i].parent >= 0; i = nodes[i].parent {
+				name = append(name, nodes[i].c)
+			}
+			for l, r := 0, len(name)-1; l < r; l, r = l+1, r-1 {
+				name[l], name[r] = name[r], name[l]
+			}
+			return string(name), true, nil
+		}
+
+		for _, c := range bytes {
+			na, ok := nextStates(a, n.a, c)
+			if !ok {
+				// No longer name is matched by a.
+				continue
+			}
+			nb, _ := nextStates(b, n.b, c)
+			key := statesKey(na, nb)
+			if seen[key] {
+				continue
+			}
+			if len(seen) == maxSearchStates {
+				return "", false, ErrTooComplex
+			}
+			seen[key] = true
+			nodes = append(nodes, node{a: na, b: nb, parent: i, c: c})
+		}
+	}
+	return "", false, nil
+}
+
+// alphabet - returns the literal bytes of both patterns followed by a byte
+// neither of them has, if any.
+func alphabet(a, b string) []byte {
+	var used [256]bool
+	for _, pattern := range []string{a, b} {
+		for i := 0; i < len(pattern); i++ {
+			if pattern[i] != '*' && pattern[i] != '?' {
+				used[pattern[i]] = true
+			}
+		}
+	}
+
+	var bytes []byte
+	for c := range 256 {
+		if used[c] {
+			bytes = append(bytes, byte(c))
+		}
+	}
+	// Prefer a readable byte in witnesses.
+	for _, c := range []byte("xyz0123456789abcdefghijklmnopqrstuvw") {
+		if !used[c] {
+			return append(bytes, c)
+		}
+	}
+	for c := range 256 {
+		if !used[c] {
+			return append(bytes, byte(c))
+		}
+	}
+	return bytes
+}
+
+// startStates - returns the states of the pattern before any byte is matched.
+func startStates(pattern string) []bool {
+	states := make([]bool, len(pattern)+1)
+	addState(pattern, states, 0)
+	return states
+}
+
+// nextStates - returns the states of the pattern after matching byte c in any
+// of the given states, and whether there is any.
+func nextStates(pattern string, states []bool, c byte) ([]bool, bool) {
+	next := make([]bool, len(states))
+	ok := false
+	for k := 0; k < len(pattern); k++ {
+		if !states[k] {
+			continue
+		}
+		switch pattern[k] {
+		case '*':
+			addState(pattern, next, k)
+		case '?':
+			addState(pattern, next, k+1)
+		default:
+			if pattern[k] != c {
+				continue
+			}
+			addState(pattern, next, k+1)
+		}
+		ok = true
+	}
+	return next, ok
+}
+
+// addState - adds state k and the states after the stars following it, as
+// they may match the empty string.
+func addState(pattern string, states []bool, k int) {
+	for {
+		states[k] = true
+		if k == len(pattern) || pattern[k] != '*' {
+			return
+		}
+		k++
+	}
+}
+
+func statesKey(a, b []bool) string {
+	key := make([]byte, 0, len(a)+len(b))
+	for _, states := range [][]bool{a, b} {
+		for _, s := range states {
+			if s {
+				key = append(key, '1')
+			} else {
+				key = append(key, '0')
+			}
+		}
+	}
+	return string(key)
+}
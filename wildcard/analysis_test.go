@@ -0,0 +1,176 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package wildcard
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestIsSubset(t *testing.T) {
+	testCases := []struct {
+		a               string
+		b               string
+		expectedResult  bool
+		expectedWitness string
+	}{
+		{"", "", true, ""},
+		{"", "*", true, ""},
+		{"*", "", false, "x"},
+		{"my-bucket/photo.jpg", "my-bucket/*", true, ""},
+		{"my-bucket/photo.jpg", "my-bucket/*.png", false, "my-bucket/photo.jpg"},
+		{"my-bucket/photos/*", "my-bucket/*", true, ""},
+		{"my-bucket/*", "my-bucket/photos/*", false, "my-bucket/"},
+		{"my-bucket/*.jpg", "*.jpg", true, ""},
+		{"my-bucket/?", "my-bucket/*", true, ""},
+		{"my-bucket/*", "my-bucket/?*", false, "my-bucket/"},
+		{"my-bucket/?*", "my-bucket/*?", true, ""},
+		{"my-bucket/*?", "my-bucket/?*", true, ""},
+		{"a*b*c", "a*c", true, ""},
+		{"a*c", "a*b*c", false, "ac"},
+		{"*a*", "*", true, ""},
+		{"*", "*a*", false, ""},
+		{"??", "*?", true, ""},
+		{"*?", "??", false, "x"},
+		{"s3:Get*", "s3:*", true, ""},
+		{"s3:*Object", "s3:Get*", false, "s3:Object"},
+	}
+
+	for i, testCase := range testCases {
+		result, witness, err := IsSubset(testCase.a, testCase.b)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+		if witness != testCase.expectedWitness {
+			t.Fatalf("case %v: witness: expected: %q, got: %q", i+1, testCase.expectedWitness, witness)
+		}
+	}
+}
+
+func TestIntersects(t *testing.T) {
+	testCases := []struct {
+		a               string
+		b               string
+		expectedResult  bool
+		expectedWitness string
+	}{
+		{"", "", true, ""},
+		{"", "*", true, ""},
+		{"", "?", false, ""},
+		{"my-bucket/photo.jpg", "my-bucket/*", true, "my-bucket/photo.jpg"},
+		{"my-bucket/*", "my-bucket/photo.jpg", true, "my-bucket/photo.jpg"},
+		{"my-bucket/*", "your-bucket/*", false, ""},
+		{"my-bucket/*", "*.jpg", true, "my-bucket/.jpg"},
+		{"*.jpg", "*.png", false, ""},
+		{"photos/*", "*/2026/*", true, "photos/2026/"},
+		{"a*", "*b", true, "ab"},
+		{"a?", "?b", true, "ab"},
+		{"a?c", "?b", false, ""},
+		{"???", "*?*?*", true, "xxx"},
+	}
+
+	for i, testCase := range testCases {
+		result, witness, err := Intersects(testCase.a, testCase.b)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+		if witness != testCase.expectedWitness {
+			t.Fatalf("case %v: witness: expected: %q, got: %q", i+1, testCase.expectedWitness, witness)
+		}
+		if result, _, _ = Intersects(testCase.b, testCase.a); result != testCase.expectedResult {
+			t.Fatalf("case %v: reversed: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+// allStrings - returns all strings of up to n bytes of the alphabet.
+func allStrings(alphabet string, n int) []string {
+	names := []string{""}
+	for i := 0; i < len(names); i++ {
+		if len(names[i]) == n {
+			continue
+		}
+		for j := 0; j < len(alphabet); j++ {
+			names = append(names, names[i]+alphabet[j:j+1])
+		}
+	}
+	return names
+}
+
+func TestAnalysisRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	// The shortest witnesses for patterns of up to 4 bytes are among
+	// these names, so they decide both relations.
+	names := allStrings("abx", 9)
+	for range 1000 {
+		a, b := randomString(r, "ab*?", 4), randomString(r, "ab*?", 4)
+
+		subset, intersects := true, false
+		for _, name := range names {
+			matchedA, matchedB := Match(a, name), Match(b, name)
+			subset = subset && (!matchedA || matchedB)
+			intersects = intersects || matchedA && matchedB
+		}
+
+		result, witness, err := IsSubset(a, b)
+		if err != nil {
+			t.Fatalf("IsSubset(%q, %q): unexpected error. %v\n", a, b, err)
+		}
+		if result != subset {
+			t.Fatalf("IsSubset(%q, %q): expected: %v, got: %v", a, b, subset, result)
+		}
+		if !result && (!Match(a, witness) || Match(b, witness)) {
+			t.Fatalf("IsSubset(%q, %q): invalid witness %q", a, b, witness)
+		}
+
+		result, witness, err = Intersects(a, b)
+		if err != nil {
+			t.Fatalf("Intersects(%q, %q): unexpected error. %v\n", a, b, err)
+		}
+		if result != intersects {
+			t.Fatalf("Intersects(%q, %q): expected: %v, got: %v", a, b, intersects, result)
+		}
+		if result && (!Match(a, witness) || !Match(b, witness)) {
+			t.Fatalf("Intersects(%q, %q): invalid witness %q", a, b, witness)
+		}
+	}
+}
+
+func TestAnalysisTooComplex(t *testing.T) {
+	// Every '?' after the star doubles the sets of states to visit.
+	a, b := "*a"+strings.Repeat("?", 32)+"b", "*c"
+
+	if _, _, err := IsSubset(a, b); !errors.Is(err, ErrTooComplex) {
+		t.Fatalf("IsSubset: expected: %v, got: %v", ErrTooComplex, err)
+	}
+	if _, _, err := Intersects(a, b); !errors.Is(err, ErrTooComplex) {
+		t.Fatalf("Intersects: expected: %v, got: %v", ErrTooComplex, err)
+	}
+	// Fewer states are still decided.
+	if result, _, err := IsSubset("*a????b", "*c"); err != nil || result {
+		t.Fatalf("expected: false, got: %v, %v", result, err)
+	}
+}
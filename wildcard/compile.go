@@ -0,0 +1,159 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package wildcard
+
+import "strings"
+
+type matchKind uint8
+
+const (
+	// matchExact - pattern without wildcards.
+	matchExact matchKind = iota
+	// matchAffix - pattern of the form prefix*suffix.
+	matchAffix
+	// matchGeneral - any other pattern.
+	matchGeneral
+)
+
+// Matcher - compiled pattern, which matches names like Match() or
+// MatchSimple() without interpreting the pattern again on every call. It is
+// immutable and safe for concurrent use.
+type Matcher struct {
+	pattern string
+	kind    matchKind
+	// prefix and suffix are the literal parts of the pattern before its
+	// first and after its last wildcard, middle is the part in between.
+	prefix string
+	suffix string
+	middle string
+	// optional holds the matchers of the pattern up to each '?' for
+	// MatchSimple(), which matches names ending before a '?'.
+	optional []*Matcher
+}
+
+// Compile - returns a matcher of the pattern, which matches names like
+// Match(pattern, name).
+func Compile(pattern string) *Matcher {
+	m := &Matcher{pattern: pattern}
+
+	first := strings.IndexAny(pattern, "*?")
+	if first < 0 {
+		m.kind = matchExact
+		return m
+	}
+	last := strings.LastIndexAny(pattern, "*?")
+
+	m.prefix, m.middle, m.suffix = pattern[:first], pattern[first:last+1], pattern[last+1:]
+	if m.middle == "*" {
+		m.kind = matchAffix
+	} else {
+		m.kind = matchGeneral
+	}
+	return m
+}
+
+// CompileSimple - returns a matcher of the pattern, which matches names like
+// MatchSimple(pattern, name).
+func CompileSimple(pattern string) *Matcher {
+	m := Compile(pattern)
+	if pattern == "" || pattern == "*" {
+		return m
+	}
+
+	// MatchSimple() also matches names ending where the pattern has a '?',
+	// i.e. names matching the pattern up to it.
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] == '?' {
+			m.optional = append(m.optional, Compile(pattern[:i]))
+		}
+	}
+	return m
+}
+
+// String - returns the pattern of the matcher.
+func (m *Matcher) String() string {
+	return m.pattern
+}
+
+// Match - returns whether the name matches the compiled pattern.
+func (m *Matcher) Match(name string) bool {
+	if m.match(name) {
+		return true
+	}
+	for _, optional := range m.optional {
+		if optional.match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Matcher) match(name string) bool {
+	switch m.kind {
+	case matchExact:
+		return name == m.pattern
+	case matchAffix:
+		return len(name) >= len(m.prefix)+len(m.suffix) &&
+			strings.HasPrefix(name, m.prefix) && strings.HasSuffix(name, m.suffix)
+	}
+
+	if len(name) < len(m.prefix)+len(m.suffix) ||
+		!strings.HasPrefix(name, m.prefix) || !strings.HasSuffix(name, m.suffix) {
+		return false
+	}
+	return matchWildcards(m.middle, name[len(m.prefix):len(name)-len(m.suffix)])
+}
+
+// matchWildcards - matches str against pattern like Match(), backtracking
+// only to the last '*' seen so that the time is at most proportional to the
+// product of their lengths.
+func matchWildcards(pattern, str string) bool {
+	p, s := 0, 0
+	starP, starS := -1, 0
+	for {
+		if p < len(pattern) {
+			switch pattern[p] {
+			case '*':
+				starP, starS = p, s
+				p++
+				continue
+			case '?':
+				if s < len(str) {
+					p++
+					s++
+					continue
+				}
+			default:
+				if s < len(str) && str[s] == pattern[p] {
+					p++
+					s++
+					continue
+				}
+			}
+		} else if s == len(str) {
+			return true
+		}
+
+		// Let the last star match one more byte and retry from there.
+		if starP < 0 || starS == len(str) {
+			return false
+		}
+		starS++
+		p, s = starP+1, starS
+	}
+}
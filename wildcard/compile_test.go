@@ -0,0 +1,122 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package wildcard
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	testCases := []struct {
+		pattern       string
+		name          string
+		matched       bool
+		matchedSimple bool
+	}{
+		{"", "", true, true},
+		{"", "a", false, false},
+		{"*", "", true, true},
+		{"*", "my-bucket/oo*o", true, true},
+		{"my-bucket/oo", "my-bucket/oo", true, true},
+		{"my-bucket/oo", "my-bucket/ooo", false, false},
+		{"my-bucket/*", "my-bucket/", true, true},
+		{"my-bucket/*", "my-bucket", false, false},
+		{"*.jpg", "my-bucket/photo.jpg", true, true},
+		{"*.jpg", "my-bucket/photo.png", false, false},
+		{"my-bucket/*.jpg", "my-bucket.jpg", false, false},
+		{"my-bucket/*/*.jpg", "my-bucket/a/b/c.jpg", true, true},
+		{"my-bucket/?", "my-bucket/a", true, true},
+		{"my-bucket/?", "my-bucket/", false, true},
+		{"my-bucket/?", "my-bucket/ab", false, false},
+		{"my-bucket/??x", "my-bucket/a", false, true},
+		{"my-bucket/*?", "my-bucket/", false, true},
+		{"a*b*c", "abbbc", true, true},
+		{"a*b*c", "abcbd", false, false},
+		{"a*?b", "ab", false, true},
+		{"a*?b", "axb", true, true},
+		{"*a*b*c*", "xaybzc", true, true},
+		{"s3:Get*", "s3:GetObject", true, true},
+	}
+
+	for i, testCase := range testCases {
+		if matched := Compile(testCase.pattern).Match(testCase.name); matched != testCase.matched {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.matched, matched)
+		}
+		if matched := CompileSimple(testCase.pattern).Match(testCase.name); matched != testCase.matchedSimple {
+			t.Fatalf("case %v: simple: expected: %v, got: %v", i+1, testCase.matchedSimple, matched)
+		}
+		if matched := Match(testCase.pattern, testCase.name); matched != testCase.matched {
+			t.Fatalf("case %v: Match: expected: %v, got: %v", i+1, testCase.matched, matched)
+		}
+		if matched := MatchSimple(testCase.pattern, testCase.name); matched != testCase.matchedSimple {
+			t.Fatalf("case %v: MatchSimple: expected: %v, got: %v", i+1, testCase.matchedSimple, matched)
+		}
+	}
+}
+
+// randomString - returns a random string of up to n bytes of the alphabet.
+func randomString(r *rand.Rand, alphabet string, n int) string {
+	var sb strings.Builder
+	for range r.Intn(n + 1) {
+		sb.WriteByte(alphabet[r.Intn(len(alphabet))])
+	}
+	return sb.String()
+}
+
+func TestCompileRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for range 100000 {
+		pattern := randomString(r, "ab*?", 8)
+		name := randomString(r, "ab", 8)
+
+		if expected, got := Match(pattern, name), Compile(pattern).Match(name); expected != got {
+			t.Fatalf("pattern %q, name %q: expected: %v, got: %v", pattern, name, expected, got)
+		}
+		if expected, got := MatchSimple(pattern, name), CompileSimple(pattern).Match(name); expected != got {
+			t.Fatalf("simple pattern %q, name %q: expected: %v, got: %v", pattern, name, expected, got)
+		}
+	}
+}
+
+func BenchmarkCompile(b *testing.B) {
+	name := "my-bucket/" + strings.Repeat("photos/2026/", 10) + "IMG_0001.jpg"
+	patterns := []string{
+		"my-bucket/photos/2026/*",
+		"*.jpg",
+		"my-bucket/*/IMG_????.jpg",
+		"my-bucket/*2026*2026*.png",
+	}
+
+	for _, pattern := range patterns {
+		b.Run("Match/"+pattern, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				Match(pattern, name)
+			}
+		})
+		b.Run("Compiled/"+pattern, func(b *testing.B) {
+			m := Compile(pattern)
+			b.ReportAllocs()
+			for b.Loop() {
+				m.Match(name)
+			}
+		})
+	}
+}
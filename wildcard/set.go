@@ -0,0 +1,130 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package wildcard
+
+import "sort"
+
+// setNode - node of a byte trie over the literal prefixes of patterns.
+type setNode struct {
+	children map[byte]*setNode
+	patterns []int
+}
+
+// Set - matches a name against many patterns at once. Patterns without
+// wildcards are looked up directly, the others are indexed by their literal
+// prefix so that only patterns sharing a prefix with the name are checked.
+// It is immutable and safe for concurrent use.
+type Set struct {
+	matchers []*Matcher
+	exact    map[string][]int
+	root     setNode
+}
+
+func newSet(patterns []string, compile func(string) *Matcher) *Set {
+	s := &Set{
+		matchers: make([]*Matcher, len(patterns)),
+		exact:    make(map[string][]int),
+	}
+
+	for i, pattern := range patterns {
+		m := compile(pattern)
+		s.matchers[i] = m
+		if m.kind == matchExact {
+			s.exact[pattern] = append(s.exact[pattern], i)
+			continue
+		}
+
+		node := &s.root
+		for j := 0; j < len(m.prefix); j++ {
+			child, ok := node.children[m.prefix[j]]
+			if !ok {
+				if node.children == nil {
+					node.children = make(map[byte]*setNode)
+				}
+				child = &setNode{}
+				node.children[m.prefix[j]] = child
+			}
+			node = child
+		}
+		node.patterns = append(node.patterns, i)
+	}
+	return s
+}
+
+// NewSet - returns a set of the patterns, which matches names like Match().
+func NewSet(patterns ...string) *Set {
+	return newSet(patterns, Compile)
+}
+
+// NewSimpleSet - returns a set of the patterns, which matches names like
+// MatchSimple().
+func NewSimpleSet(patterns ...string) *Set {
+	return newSet(patterns, CompileSimple)
+}
+
+// Len - returns the number of patterns in the set.
+func (s *Set) Len() int {
+	return len(s.matchers)
+}
+
+// Pattern - returns the pattern of given index.
+func (s *Set) Pattern(i int) string {
+	return s.matchers[i].pattern
+}
+
+// visit - calls fn with the index of every pattern matching the name until
+// fn returns true.
+func (s *Set) visit(name string, fn func(i int) bool) bool {
+	for _, i := range s.exact[name] {
+		if fn(i) {
+			return true
+		}
+	}
+
+	node := &s.root
+	for j := 0; ; j++ {
+		for _, i := range node.patterns {
+			if s.matchers[i].Match(name) && fn(i) {
+				return true
+			}
+		}
+		if j == len(name) {
+			return false
+		}
+		if node = node.children[name[j]]; node == nil {
+			return false
+		}
+	}
+}
+
+// Match - returns the indexes of the patterns matching the name in
+// ascending order.
+func (s *Set) Match(name string) []int {
+	var matched []int
+	s.visit(name, func(i int) bool {
+		matched = append(matched, i)
+		return false
+	})
+	sort.Ints(matched)
+	return matched
+}
+
+// MatchAny - returns whether any pattern matches the name.
+func (s *Set) MatchAny(name string) bool {
+	return s.visit(name, func(int) bool { return true })
+}
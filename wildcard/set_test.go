@@ -0,0 +1,131 @@
+// Copyright (c) 2015-2026 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package wildcard
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	set := NewSet(
+		"my-bucket/*",
+		"my-bucket/photos/*.jpg",
+		"*.jpg",
+		"my-bucket/photos/a.jpg",
+		"other/*",
+		"my-bucket/photos/?.jpg",
+		"my-bucket/photos/a.jpg",
+	)
+	simpleSet := NewSimpleSet("my-bucket/?", "my-bucket")
+
+	testCases := []struct {
+		set            *Set
+		name           string
+		expectedResult []int
+	}{
+		{set, "my-bucket/photos/a.jpg", []int{0, 1, 2, 3, 5, 6}},
+		{set, "my-bucket/photos/ab.jpg", []int{0, 1, 2}},
+		{set, "my-bucket/doc.txt", []int{0}},
+		{set, "your-bucket/a.jpg", []int{2}},
+		{set, "your-bucket/a.png", nil},
+		{set, "", nil},
+		{simpleSet, "my-bucket/", []int{0}},
+		{simpleSet, "my-bucket", []int{1}},
+		{simpleSet, "my-bucket/ab", nil},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.set.Match(testCase.name)
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+		if matched := testCase.set.MatchAny(testCase.name); matched != (len(testCase.expectedResult) > 0) {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, len(testCase.expectedResult) > 0, matched)
+		}
+	}
+
+	if set.Len() != 7 || set.Pattern(2) != "*.jpg" {
+		t.Fatalf("unexpected set %v, %v", set.Len(), set.Pattern(2))
+	}
+}
+
+func TestSetRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	patterns := make([]string, 200)
+	for i := range patterns {
+		patterns[i] = randomString(r, "ab*?", 6)
+	}
+	set, simpleSet := NewSet(patterns...), NewSimpleSet(patterns...)
+
+	for range 10000 {
+		name := randomString(r, "ab", 6)
+		var expected, expectedSimple []int
+		for i, pattern := range patterns {
+			if Match(pattern, name) {
+				expected = append(expected, i)
+			}
+			if MatchSimple(pattern, name) {
+				expectedSimple = append(expectedSimple, i)
+			}
+		}
+
+		if result := set.Match(name); !reflect.DeepEqual(result, expected) {
+			t.Fatalf("name %q: expected: %v, got: %v", name, expected, result)
+		}
+		if result := simpleSet.Match(name); !reflect.DeepEqual(result, expectedSimple) {
+			t.Fatalf("simple name %q: expected: %v, got: %v", name, expectedSimple, result)
+		}
+	}
+}
+
+func BenchmarkSet(b *testing.B) {
+	for _, n := range []int{10, 1000, 10000} {
+		patterns := make([]string, n)
+		for i := range patterns {
+			switch i % 3 {
+			case 0:
+				patterns[i] = fmt.Sprintf("bucket%d/*", i)
+			case 1:
+				patterns[i] = fmt.Sprintf("bucket%d/photos/*.jpg", i)
+			default:
+				patterns[i] = fmt.Sprintf("bucket%d/docs/report-????.pdf", i)
+			}
+		}
+		name := fmt.Sprintf("bucket%d/photos/IMG_0001.jpg", n/2+1)
+
+		b.Run(fmt.Sprintf("Match/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				for _, pattern := range patterns {
+					Match(pattern, name)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Set/%d", n), func(b *testing.B) {
+			set := NewSet(patterns...)
+			b.ReportAllocs()
+			for b.Loop() {
+				set.Match(name)
+			}
+		})
+	}
+}